@@ -0,0 +1,64 @@
+// automation polls a MIYO Cube and executes user defined irrigation rules.
+// See package github.com/octo/miyo-go/miyo/rules for the format of the rules file.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/octo/miyo-go/miyo"
+	"github.com/octo/miyo-go/miyo/rules"
+)
+
+var (
+	address   = flag.String("addr", os.Getenv("MIYO_ADDRESS"), "address of the Miyo cube")
+	apiKey    = flag.String("apikey", os.Getenv("MIYO_APIKEY"), "API key of the Miyo cube")
+	timeZone  = flag.String("tz", "", `time zone of the Miyo cube, e.g. "Europe/Berlin"`)
+	rulesFile = flag.String("rules", "rules.yaml", "YAML file with irrigation rules")
+	dryRun    = flag.Bool("dry-run", false, "report actions without executing them")
+	stateFile = flag.String("state", "automation-state.json", "file pending stops and rule state are kept in across restarts")
+	interval  = flag.Duration("interval", time.Minute, "interval in which the Miyo cube is polled")
+)
+
+func main() {
+	ctx := context.Background()
+	flag.Parse()
+
+	if *address == "" || *apiKey == "" {
+		fmt.Fprintf(os.Stderr, "Usage: %s -addr=<addr> -apikey=<apikey> -rules=<file> [-dry-run]\n", os.Args[0])
+		os.Exit(1)
+	}
+
+	data, err := os.ReadFile(*rulesFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	rs, err := rules.Parse(data)
+	if err != nil {
+		log.Fatalf("%s: %v", *rulesFile, err)
+	}
+
+	loc, err := miyo.LoadLocation(*timeZone)
+	if err != nil {
+		log.Fatalf("time zone: %v", err)
+	}
+	conn, err := miyo.Connect(ctx, *address, *apiKey, miyo.WithLocation(loc))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	e := &rules.Engine{
+		Rules:      rs,
+		Controller: conn,
+		DryRun:     *dryRun,
+		StateFile:  *stateFile,
+	}
+	if err := e.Restore(); err != nil {
+		log.Fatal(err)
+	}
+	log.Fatal(conn.Poll(ctx, *interval, e.Update))
+}
@@ -0,0 +1,99 @@
+// battery-report prints a weekly report of the energy balance of all valves and sensors, based on the history
+// written by recorder, and predicts when each of them will run low on power.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/octo/miyo-go/miyo"
+	"github.com/octo/miyo-go/miyo/energy"
+	"github.com/octo/miyo-go/miyo/history"
+)
+
+var (
+	address  = flag.String("addr", os.Getenv("MIYO_ADDRESS"), "address of the Miyo cube")
+	apiKey   = flag.String("apikey", os.Getenv("MIYO_APIKEY"), "API key of the Miyo cube")
+	timeZone = flag.String("tz", "", `time zone of the Miyo cube, e.g. "Europe/Berlin"`)
+	dir      = flag.String("dir", "miyo-history", "directory of the history store")
+	window   = flag.Duration("window", 28*24*time.Hour, "duration of history used for the trend")
+	jsonOut  = flag.Bool("json", false, "print the report as JSON")
+)
+
+// domain is the catalog domain of the command's messages.
+const domain = "battery-report"
+
+func init() {
+	miyo.RegisterCatalog(domain, "de", miyo.Catalog{
+		"Energy balance from %s to %s": "Energiebilanz vom %s bis %s",
+		"expected %s":                  "voraussichtlich %s",
+	})
+}
+
+// tr translates msg into the current language.
+func tr(msg string) string {
+	return miyo.Translate(domain, msg)
+}
+
+func main() {
+	ctx := context.Background()
+	flag.Parse()
+	miyo.SetLanguage(miyo.LanguageFromEnv())
+
+	if *address == "" || *apiKey == "" {
+		fmt.Fprintf(os.Stderr, "Usage: %s -addr=<addr> -apikey=<apikey> [-dir=<dir>]\n", os.Args[0])
+		os.Exit(1)
+	}
+
+	store, err := history.Open(*dir, history.DefaultOptions)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	loc, err := miyo.LoadLocation(*timeZone)
+	if err != nil {
+		log.Fatalf("time zone: %v", err)
+	}
+	conn, err := miyo.Connect(ctx, *address, *apiKey, miyo.WithLocation(loc))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	snap, err := conn.Snapshot(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	a := energy.Analyzer{
+		History: store,
+		Window:  *window,
+	}
+	r := a.Report(snap)
+
+	if *jsonOut {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(r); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	fmt.Printf(tr("Energy balance from %s to %s")+"\n\n", r.From.Format("02.01."), r.To.Format("02.01."))
+	for _, b := range r.Devices {
+		if !b.LowPower && !b.LowPowerAt.IsZero() {
+			fmt.Printf("%s (%s)\n", b, fmt.Sprintf(tr("expected %s"), b.LowPowerAt.Format("02.01.")))
+		} else {
+			fmt.Println(b)
+		}
+		fmt.Printf("  %s\n", b.Summary())
+	}
+	for _, e := range r.Errors {
+		fmt.Println(e)
+	}
+}
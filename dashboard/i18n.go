@@ -0,0 +1,75 @@
+package main
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/octo/miyo-go/miyo"
+)
+
+// domain is the catalog domain of the dashboard's messages.
+const domain = "dashboard"
+
+func init() {
+	miyo.RegisterCatalog(domain, "de", german)
+}
+
+// german is the German catalog of the dashboard.
+var german = miyo.Catalog{
+	// Headings
+	"MIYO garden status": "MIYO-Gartenstatus",
+	"  (updated %s)":     "  (aktualisiert %s)",
+	"Areas":              "Bereiche",
+	"Devices":            "Geräte",
+	"TYPE":               "TYP",
+	"REACHABLE":          "ERREICHBAR",
+	"BATTERY":            "AKKU",
+
+	// Values
+	"yes":                 "ja",
+	"no":                  "nein",
+	"ok":                  "OK",
+	"low":                 "schwach",
+	"valve":               "Ventil",
+	"moisture_sensor":     "Feuchtesensor",
+	" sensor unreachable": " Sensor nicht erreichbar",
+	"irrigating":          "bewässert",
+	"next %s":             "nächste %s",
+	"Mon 15:04":           "02.01. 15:04",
+	"auto":                "automatisch",
+	"manual":              "manuell",
+
+	// Messages
+	"Error: %v":                               "Fehler: %v",
+	"%s failed: %v":                           "%s fehlgeschlagen: %v",
+	"Refreshing …":                            "Aktualisiere …",
+	"Stopping irrigation of %s":               "Stoppe Bewässerung von %s",
+	"Starting irrigation of %s":               "Starte Bewässerung von %s",
+	"Disabling automatic mode of %s":          "Schalte Automatikmodus von %s aus",
+	"Enabling automatic mode of %s":           "Schalte Automatikmodus von %s ein",
+	"Usage: %s -addr=<addr> -apikey=<apikey>": "Aufruf: %s -addr=<Adresse> -apikey=<API-Schlüssel>",
+	"↑/↓ select area · i start/stop irrigation · a toggle automatic mode · r refresh · q quit": "↑/↓ Bereich wählen · i Bewässerung starten/stoppen · a Automatikmodus umschalten · r aktualisieren · q beenden",
+}
+
+// tr translates msg into the current language.
+func tr(msg string) string {
+	return miyo.Translate(domain, msg)
+}
+
+// deviceType returns the translated type of d, or the type reported by the MIYO Cube if it is unknown.
+func deviceType(d miyo.Device) string {
+	if d.DeviceType() == miyo.DeviceType_Unknown {
+		return d.Type
+	}
+	return tr(d.DeviceType().String())
+}
+
+// colored returns s in color, padded with spaces to width characters. Unlike "%-*s", it doesn't count the
+// escape sequences.
+func colored(color, s string, width int) string {
+	pad := width - utf8.RuneCountInString(s)
+	if pad < 0 {
+		pad = 0
+	}
+	return color + s + ansiReset + strings.Repeat(" ", pad)
+}
@@ -0,0 +1,178 @@
+// dashboard shows the live status of all areas and devices of a MIYO Cube in the terminal.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/octo/miyo-go/miyo"
+	"golang.org/x/term"
+)
+
+var (
+	address  = flag.String("addr", os.Getenv("MIYO_ADDRESS"), "address of the Miyo cube")
+	apiKey   = flag.String("apikey", os.Getenv("MIYO_APIKEY"), "API key of the Miyo cube")
+	timeZone = flag.String("tz", "", `time zone of the Miyo cube, e.g. "Europe/Berlin"`)
+	interval = flag.Duration("interval", 30*time.Second, "interval in which the Miyo cube is polled")
+)
+
+type snapshotResult struct {
+	snap miyo.Snapshot
+	err  error
+}
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	flag.Parse()
+	miyo.SetLanguage(miyo.LanguageFromEnv())
+
+	if *address == "" || *apiKey == "" {
+		fmt.Fprintf(os.Stderr, tr("Usage: %s -addr=<addr> -apikey=<apikey>")+"\n", os.Args[0])
+		os.Exit(1)
+	}
+
+	loc, err := miyo.LoadLocation(*timeZone)
+	if err != nil {
+		log.Fatalf("time zone: %v", err)
+	}
+	conn, err := miyo.Connect(ctx, *address, *apiKey, miyo.WithLocation(loc))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fd := int(os.Stdin.Fd())
+	oldState, err := term.MakeRaw(fd)
+	if err != nil {
+		log.Fatalf("switching terminal to raw mode: %v", err)
+	}
+	fmt.Print("\x1b[?25l") // hide cursor
+	defer func() {
+		fmt.Print("\x1b[?25h" + ansiClear)
+		term.Restore(fd, oldState)
+	}()
+
+	results := make(chan snapshotResult)
+	refresh := make(chan struct{}, 1)
+	go poll(ctx, conn, results, refresh)
+
+	keys := make(chan string)
+	go readKeys(keys)
+
+	m := &model{}
+	for {
+		fmt.Print(m.render())
+
+		select {
+		case res := <-results:
+			m.err = res.err
+			if res.err == nil {
+				m.snap = res.snap
+				m.moveSelection(0)
+			}
+		case key, ok := <-keys:
+			if !ok || key == "q" || key == "\x03" {
+				return
+			}
+			if handleKey(ctx, conn, m, key) {
+				select {
+				case refresh <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}
+}
+
+// poll sends a new snapshot to results every interval and whenever a value is sent to refresh.
+func poll(ctx context.Context, conn *miyo.Conn, results chan<- snapshotResult, refresh <-chan struct{}) {
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+
+	for {
+		snap, err := conn.Snapshot(ctx)
+		select {
+		case results <- snapshotResult{snap, err}:
+		case <-ctx.Done():
+			return
+		}
+
+		select {
+		case <-ticker.C:
+		case <-refresh:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// readKeys reads key presses from stdin. Arrow keys are translated to "up" and "down".
+func readKeys(keys chan<- string) {
+	defer close(keys)
+
+	buf := make([]byte, 8)
+	for {
+		n, err := os.Stdin.Read(buf)
+		if err != nil {
+			return
+		}
+
+		switch key := string(buf[:n]); key {
+		case "\x1b[A", "k":
+			keys <- "up"
+		case "\x1b[B", "j":
+			keys <- "down"
+		default:
+			keys <- key
+		}
+	}
+}
+
+// handleKey executes the action bound to key. It returns true if the MIYO Cube's state may have changed.
+func handleKey(ctx context.Context, conn *miyo.Conn, m *model, key string) bool {
+	switch key {
+	case "up":
+		m.moveSelection(-1)
+		return false
+	case "down":
+		m.moveSelection(1)
+		return false
+	case "r":
+		m.message = tr("Refreshing …")
+		return true
+	}
+
+	a, ok := m.selectedArea()
+	if !ok {
+		return false
+	}
+
+	var err error
+	switch key {
+	case "i":
+		err = conn.SetIrrigation(ctx, a.ID, !a.State.Irrigation)
+		if a.State.Irrigation {
+			m.message = fmt.Sprintf(tr("Stopping irrigation of %s"), a.Name)
+		} else {
+			m.message = fmt.Sprintf(tr("Starting irrigation of %s"), a.Name)
+		}
+	case "a":
+		err = conn.SetAutomaticMode(ctx, a.ID, !a.State.AutomaticMode)
+		if a.State.AutomaticMode {
+			m.message = fmt.Sprintf(tr("Disabling automatic mode of %s"), a.Name)
+		} else {
+			m.message = fmt.Sprintf(tr("Enabling automatic mode of %s"), a.Name)
+		}
+	default:
+		return false
+	}
+
+	if err != nil {
+		m.message = fmt.Sprintf(tr("%s failed: %v"), m.message, err)
+	}
+	return true
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/octo/miyo-go/miyo"
+)
+
+const (
+	ansiClear   = "\x1b[H\x1b[2J"
+	ansiReset   = "\x1b[0m"
+	ansiBold    = "\x1b[1m"
+	ansiReverse = "\x1b[7m"
+	ansiRed     = "\x1b[31m"
+	ansiGreen   = "\x1b[32m"
+	ansiYellow  = "\x1b[33m"
+	ansiBlue    = "\x1b[34m"
+	ansiGray    = "\x1b[90m"
+
+	barWidth = 40
+)
+
+// model holds everything displayed by the dashboard.
+type model struct {
+	snap     miyo.Snapshot
+	err      error
+	selected int
+	message  string
+}
+
+// selectedArea returns the area under the cursor.
+func (m *model) selectedArea() (miyo.Circuit, bool) {
+	if m.selected < 0 || m.selected >= len(m.snap.Areas) {
+		return miyo.Circuit{}, false
+	}
+	return m.snap.Areas[m.selected], true
+}
+
+func (m *model) moveSelection(delta int) {
+	m.selected += delta
+	if m.selected >= len(m.snap.Areas) {
+		m.selected = len(m.snap.Areas) - 1
+	}
+	if m.selected < 0 {
+		m.selected = 0
+	}
+}
+
+// render returns the entire screen. Lines are terminated with "\r\n", because the terminal is in raw mode.
+func (m *model) render() string {
+	var b strings.Builder
+	b.WriteString(ansiClear)
+
+	fmt.Fprintf(&b, "%s%s%s", ansiBold, tr("MIYO garden status"), ansiReset)
+	if !m.snap.Time.IsZero() {
+		fmt.Fprintf(&b, tr("  (updated %s)"), m.snap.Time.Format("15:04:05"))
+	}
+	b.WriteString("\n\n")
+
+	fmt.Fprintf(&b, "%s%s%s\n", ansiBold, tr("Areas"), ansiReset)
+	for i, a := range m.snap.Areas {
+		line := fmt.Sprintf("%-16s %s %s", truncate(a.Name, 16), moistureBar(a), areaFlags(a, m.snap.Location()))
+		if i == m.selected {
+			line = ansiReverse + line + ansiReset
+		}
+		b.WriteString(line + "\n")
+	}
+	b.WriteString("\n")
+
+	fmt.Fprintf(&b, "%s%s%s\n", ansiBold, tr("Devices"), ansiReset)
+	fmt.Fprintf(&b, "%-16s %-38s %-11s %5s  %-7s  %s\n", tr("TYPE"), "ID", tr("REACHABLE"), "RSSI", tr("BATTERY"), "STATUS")
+	for _, d := range m.snap.Devices {
+		reachable := colored(ansiGreen, tr("yes"), 11)
+		if !d.State.Reachable {
+			reachable = colored(ansiRed, tr("no"), 11)
+		}
+		battery := colored("", tr("ok"), 7)
+		if d.State.LowPower {
+			battery = colored(ansiRed, tr("low"), 7)
+		}
+		fmt.Fprintf(&b, "%-16s %-38s %s %5d  %s  %s\n", deviceType(d), d.ID, reachable, d.State.RSSI, battery, d.Status())
+	}
+	b.WriteString("\n")
+
+	if m.err != nil {
+		fmt.Fprintf(&b, "%s%s%s\n", ansiRed, fmt.Sprintf(tr("Error: %v"), m.err), ansiReset)
+	}
+	if m.message != "" {
+		fmt.Fprintf(&b, "%s\n", m.message)
+	}
+	fmt.Fprintf(&b, "%s%s%s\n", ansiGray, tr("↑/↓ select area · i start/stop irrigation · a toggle automatic mode · r refresh · q quit"), ansiReset)
+
+	return strings.ReplaceAll(b.String(), "\n", "\r\n")
+}
+
+// moistureBar draws the area's moisture as a bar of barWidth characters.
+// The lower and upper moisture bounds are marked with "|".
+func moistureBar(a miyo.Circuit) string {
+	if !a.SensorData.State.Reachable {
+		return ansiGray + "[" + fmt.Sprintf("%-*s", barWidth, tr(" sensor unreachable")) + "]" + ansiReset
+	}
+
+	moisture := a.SensorData.State.Moisture
+	color := ansiGreen
+	switch {
+	case a.Params.BelowLowerBound(moisture):
+		color = ansiRed
+	case a.Params.DistanceToTarget(moisture) < 0:
+		color = ansiBlue
+	}
+
+	pos := func(percent int) int {
+		return percent * barWidth / 100
+	}
+
+	cells := make([]string, barWidth)
+	for i := range cells {
+		if i < pos(moisture) {
+			cells[i] = color + "█" + ansiReset
+		} else {
+			cells[i] = " "
+		}
+	}
+	for _, p := range []miyo.Percent{a.Params.BorderBottom, a.Params.BorderTop} {
+		if pos(int(p)) >= barWidth {
+			continue
+		}
+		cells[pos(int(p))] = ansiYellow + "|" + ansiReset
+	}
+
+	return fmt.Sprintf("[%s] %3d%%", strings.Join(cells, ""), moisture)
+}
+
+func areaFlags(a miyo.Circuit, loc *time.Location) string {
+	var flags []string
+	if a.State.Irrigation {
+		flags = append(flags, ansiBlue+tr("irrigating")+ansiReset)
+	} else if start, _ := a.State.NextIrrigation(); !start.IsZero() {
+		flags = append(flags, fmt.Sprintf(tr("next %s"), start.In(loc).Format(tr("Mon 15:04"))))
+	}
+	if a.State.AutomaticMode {
+		flags = append(flags, tr("auto"))
+	} else {
+		flags = append(flags, ansiYellow+tr("manual")+ansiReset)
+	}
+	return strings.Join(flags, " · ")
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
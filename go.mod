@@ -3,6 +3,12 @@ module github.com/octo/miyo-go
 go 1.16
 
 require (
+	github.com/eclipse/paho.mqtt.golang v1.3.5
 	github.com/google/go-cmp v0.5.7
 	github.com/koron/go-ssdp v0.0.2
+	go.starlark.net v0.0.0-20220302181546-5411bad688d1
+	golang.org/x/term v0.0.0-20201126162022-7de9c90e9dd1
+	google.golang.org/grpc v1.40.0
+	google.golang.org/protobuf v1.27.1
+	gopkg.in/yaml.v3 v3.0.1
 )
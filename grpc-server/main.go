@@ -0,0 +1,62 @@
+// grpc-server serves the gRPC service defined in proto/miyo/v1/miyo.proto for a MIYO Cube.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"time"
+
+	"github.com/octo/miyo-go/miyo"
+	"github.com/octo/miyo-go/miyo/grpcserver"
+	pb "github.com/octo/miyo-go/miyo/miyopb"
+	"google.golang.org/grpc"
+)
+
+var (
+	address  = flag.String("addr", os.Getenv("MIYO_ADDRESS"), "address of the Miyo cube")
+	apiKey   = flag.String("apikey", os.Getenv("MIYO_APIKEY"), "API key of the Miyo cube")
+	timeZone = flag.String("tz", "", `time zone of the Miyo cube, e.g. "Europe/Berlin"`)
+	listen   = flag.String("listen", ":9090", "address to listen on")
+	interval = flag.Duration("interval", time.Minute, "interval in which the Miyo cube is polled")
+)
+
+func main() {
+	ctx := context.Background()
+	flag.Parse()
+
+	if *address == "" || *apiKey == "" {
+		fmt.Fprintf(os.Stderr, "Usage: %s -addr=<addr> -apikey=<apikey> [-listen=<addr>]\n", os.Args[0])
+		os.Exit(1)
+	}
+
+	loc, err := miyo.LoadLocation(*timeZone)
+	if err != nil {
+		log.Fatalf("time zone: %v", err)
+	}
+	conn, err := miyo.Connect(ctx, *address, *apiKey, miyo.WithLocation(loc))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	srv := &grpcserver.Server{
+		Cube: conn,
+	}
+	go func() {
+		log.Fatal(conn.Poll(ctx, *interval, srv.Update))
+	}()
+
+	lis, err := net.Listen("tcp", *listen)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	gs := grpc.NewServer()
+	pb.RegisterMiyoServer(gs, srv)
+
+	log.Printf("Listening on %s", lis.Addr())
+	log.Fatal(gs.Serve(lis))
+}
@@ -0,0 +1,59 @@
+package advisor
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/octo/miyo-go/miyo"
+)
+
+// Difference is a parameter that differs from the recommendation.
+type Difference struct {
+	// Param is the name of the parameter as used by the MIYO API, e.g. "borderBottom" or "day0".
+	Param       string
+	Current     string
+	Recommended string
+	Reason      string
+}
+
+func (d Difference) String() string {
+	return fmt.Sprintf("%s: %q → %q (%s)", d.Param, d.Current, d.Recommended, d.Reason)
+}
+
+// Advice compares the parameters of a circuit to the recommendation.
+type Advice struct {
+	Circuit        miyo.Circuit
+	Recommendation Recommendation
+	// Differences is empty if the circuit's parameters match the recommendation.
+	Differences []Difference
+}
+
+// Advise compares the parameters of c to the recommendation for its plant, soil, location and irrigation type.
+func Advise(c miyo.Circuit) Advice {
+	p := c.Params
+	r := Recommend(p)
+	a := Advice{
+		Circuit:        c,
+		Recommendation: r,
+	}
+
+	compare := func(param, current, recommended, reason string) {
+		if current == recommended {
+			return
+		}
+		a.Differences = append(a.Differences, Difference{
+			Param:       param,
+			Current:     current,
+			Recommended: recommended,
+			Reason:      reason,
+		})
+	}
+
+	compare("borderBottom", strconv.Itoa(int(p.BorderBottom)), strconv.Itoa(r.BorderBottom), r.ThresholdReason)
+	compare("borderTop", strconv.Itoa(int(p.BorderTop)), strconv.Itoa(r.BorderTop), r.ThresholdReason)
+	for day, windows := range p.Schedule() {
+		compare(fmt.Sprintf("day%d", day), windows, r.Windows, r.WindowsReason)
+	}
+
+	return a
+}
@@ -0,0 +1,86 @@
+package advisor
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/octo/miyo-go/miyo"
+)
+
+func TestRecommend(t *testing.T) {
+	cases := []struct {
+		name   string
+		params miyo.CircuitParams
+		want   Recommendation
+	}{
+		{
+			name:   "unknown plant type",
+			params: miyo.CircuitParams{PlantType: 9, SoilType: miyo.SoilType_Loamy, LocationType: 1, IrrigationType: 1},
+			want: Recommendation{
+				BorderBottom: 40, BorderTop: 60, Windows: "05:00-08:00;19:00-22:00",
+				ThresholdReason: "general recommendation",
+				WindowsReason:   "general recommendation",
+			},
+		},
+		{
+			name:   "lawn on sunny sandy soil with sprinkler",
+			params: miyo.CircuitParams{PlantType: 0, SoilType: miyo.SoilType_Sandy, LocationType: 0, IrrigationType: 0},
+			want: Recommendation{
+				BorderBottom: 40, BorderTop: 55, Windows: "04:00-08:00",
+				ThresholdReason: "lawn on sunny, sandy soil browns quickly",
+				WindowsReason:   "sprinklers should run in the early morning so leaves dry during the day",
+			},
+		},
+		{
+			name:   "vegetables on sunny sandy soil with drip",
+			params: miyo.CircuitParams{PlantType: 3, SoilType: miyo.SoilType_Sandy, LocationType: 0, IrrigationType: 1},
+			want: Recommendation{
+				BorderBottom: 50, BorderTop: 70, Windows: "05:00-07:00;12:00-13:00;19:00-21:00",
+				ThresholdReason: "vegetables need consistently moist soil",
+				WindowsReason:   "drip irrigation on sunny, sandy soil needs a midday top-up",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		got := Recommend(tc.params)
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("%s: Recommend() differs (-want/+got):\n%s", tc.name, diff)
+		}
+	}
+}
+
+func TestAdvise(t *testing.T) {
+	const windows = "05:00-08:00;19:00-22:00"
+	c := miyo.Circuit{
+		Name: "Rosen",
+		Params: miyo.CircuitParams{
+			BorderBottom:   50,
+			BorderTop:      60,
+			Day0:           windows,
+			Day1:           windows,
+			Day2:           windows,
+			Day3:           windows,
+			Day4:           windows,
+			Day5:           windows,
+			PlantType:      2,
+			SoilType:       miyo.SoilType_Unknown,
+			IrrigationType: 1,
+		},
+	}
+
+	got := Advise(c).Differences
+	want := []Difference{
+		{Param: "borderBottom", Current: "50", Recommended: "40", Reason: "shrubs root deeply and prefer infrequent, deep watering"},
+		{Param: "day6", Current: "", Recommended: windows, Reason: "general recommendation"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Advise() differs (-want/+got):\n%s", diff)
+	}
+
+	defer miyo.SetLanguage(miyo.CurrentLanguage())
+	miyo.SetLanguage("de")
+	if got, want := Advise(c).Differences[1].Reason, "allgemeine Empfehlung"; got != want {
+		t.Errorf("Reason = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,140 @@
+// Package advisor recommends moisture thresholds and irrigation windows for irrigation areas and explains how
+// an area's current parameters differ from the recommendation.
+//
+// The MIYO API doesn't document the meaning of the numeric plant, location and irrigation types. Like package
+// demand, this package assumes the order used by the MIYO app: plant types lawn, flower bed, shrubs, vegetables
+// and trees; location types sunny, partially shaded and shaded; irrigation types sprinkler and drip.
+package advisor
+
+import (
+	"github.com/octo/miyo-go/miyo"
+)
+
+// Any matches every value in a Key.
+const Any = -1
+
+// Key selects the circuits a profile applies to. Fields set to Any match every value.
+type Key struct {
+	PlantType      int
+	SoilType       miyo.SoilType
+	LocationType   int
+	IrrigationType int
+}
+
+// matches reports whether the key matches the parameters.
+func (k Key) matches(p miyo.CircuitParams) bool {
+	return (k.PlantType == Any || k.PlantType == p.PlantType) &&
+		(k.SoilType == Any || k.SoilType == p.SoilType) &&
+		(k.LocationType == Any || k.LocationType == p.LocationType) &&
+		(k.IrrigationType == Any || k.IrrigationType == p.IrrigationType)
+}
+
+// specificity returns the number of fields that are not Any.
+func (k Key) specificity() int {
+	n := 0
+	for _, v := range []int{k.PlantType, int(k.SoilType), k.LocationType, k.IrrigationType} {
+		if v != Any {
+			n++
+		}
+	}
+	return n
+}
+
+// Profile is a recommendation for circuits matching Key.
+// Zero values don't change the recommendation of less specific profiles.
+type Profile struct {
+	Key Key
+	// BorderBottom and BorderTop are the recommended moisture thresholds in percent.
+	BorderBottom, BorderTop int
+	// Windows are the recommended irrigation windows of every day, e.g. "05:00-08:00".
+	Windows string
+	// Reason explains the recommendation. Recommend translates it if the catalog of the "advisor" domain has an
+	// entry for it.
+	Reason string
+}
+
+// Profiles is the built-in profile table. Recommend combines all matching profiles, more specific ones taking
+// precedence.
+var Profiles = []Profile{
+	{Key: Key{Any, Any, Any, Any}, BorderBottom: 40, BorderTop: 60, Windows: "05:00-08:00;19:00-22:00", Reason: "general recommendation"},
+
+	// Plant types
+	{Key: Key{0, Any, Any, Any}, BorderBottom: 35, BorderTop: 55, Reason: "lawn tolerates short dry spells and roots shallowly"},
+	{Key: Key{1, Any, Any, Any}, BorderBottom: 45, BorderTop: 65, Reason: "flowers need even moisture"},
+	{Key: Key{2, Any, Any, Any}, BorderBottom: 40, BorderTop: 60, Reason: "shrubs root deeply and prefer infrequent, deep watering"},
+	{Key: Key{3, Any, Any, Any}, BorderBottom: 50, BorderTop: 70, Reason: "vegetables need consistently moist soil"},
+	{Key: Key{4, Any, Any, Any}, BorderBottom: 30, BorderTop: 50, Reason: "established trees need little irrigation"},
+
+	// Soil types
+	{Key: Key{Any, miyo.SoilType_Sandy, Any, Any}, Windows: "05:00-07:00;18:00-20:00", Reason: "sandy soil drains quickly; water more often in shorter windows"},
+
+	// Irrigation types
+	{Key: Key{Any, Any, Any, 0}, Windows: "04:00-08:00", Reason: "sprinklers should run in the early morning so leaves dry during the day"},
+	{Key: Key{Any, Any, 2, 0}, Windows: "05:00-09:00", Reason: "shaded areas dry slowly; sprinkle in the morning only"},
+	{Key: Key{Any, miyo.SoilType_Sandy, 0, 1}, Windows: "05:00-07:00;12:00-13:00;19:00-21:00", Reason: "drip irrigation on sunny, sandy soil needs a midday top-up"},
+
+	// Combinations
+	{Key: Key{0, miyo.SoilType_Sandy, 0, Any}, BorderBottom: 40, BorderTop: 55, Reason: "lawn on sunny, sandy soil browns quickly"},
+	{Key: Key{3, miyo.SoilType_Loamy, Any, Any}, BorderBottom: 45, BorderTop: 65, Reason: "loamy soil holds water; vegetables can dry slightly more"},
+}
+
+func init() {
+	miyo.RegisterCatalog(domain, "de", miyo.Catalog{
+		"general recommendation":                                                  "allgemeine Empfehlung",
+		"lawn tolerates short dry spells and roots shallowly":                     "Rasen verträgt kurze Trockenphasen und wurzelt flach",
+		"flowers need even moisture":                                              "Blumen brauchen gleichmäßige Feuchte",
+		"shrubs root deeply and prefer infrequent, deep watering":                 "Sträucher wurzeln tief und mögen seltenes, durchdringendes Gießen",
+		"vegetables need consistently moist soil":                                 "Gemüse braucht durchgehend feuchten Boden",
+		"established trees need little irrigation":                                "eingewachsene Bäume brauchen wenig Bewässerung",
+		"sandy soil drains quickly; water more often in shorter windows":          "Sandboden trocknet schnell; öfter in kürzeren Zeitfenstern bewässern",
+		"sprinklers should run in the early morning so leaves dry during the day": "Regner sollten früh morgens laufen, damit die Blätter tagsüber trocknen",
+		"shaded areas dry slowly; sprinkle in the morning only":                   "schattige Bereiche trocknen langsam; nur morgens beregnen",
+		"drip irrigation on sunny, sandy soil needs a midday top-up":              "Tropfbewässerung auf sonnigem Sandboden braucht mittags eine zusätzliche Gabe",
+		"lawn on sunny, sandy soil browns quickly":                                "Rasen auf sonnigem Sandboden wird schnell braun",
+		"loamy soil holds water; vegetables can dry slightly more":                "Lehmboden hält Wasser; Gemüse darf etwas stärker abtrocknen",
+	})
+}
+
+// domain is the catalog domain of this package's messages.
+const domain = "advisor"
+
+// tr translates msg of this package.
+func tr(msg string) string {
+	return miyo.Translate(domain, msg)
+}
+
+// Recommendation holds the recommended parameters of a circuit and the reasons for each of them, translated into
+// the current language.
+type Recommendation struct {
+	BorderBottom, BorderTop int
+	Windows                 string
+
+	ThresholdReason string
+	WindowsReason   string
+}
+
+// Recommend returns the recommendation for p.
+func Recommend(p miyo.CircuitParams) Recommendation {
+	var r Recommendation
+	thresholdLevel, windowsLevel := -1, -1
+
+	for _, prof := range Profiles {
+		if !prof.Key.matches(p) {
+			continue
+		}
+		level := prof.Key.specificity()
+
+		if prof.BorderTop != 0 && level >= thresholdLevel {
+			r.BorderBottom, r.BorderTop = prof.BorderBottom, prof.BorderTop
+			r.ThresholdReason = tr(prof.Reason)
+			thresholdLevel = level
+		}
+		if prof.Windows != "" && level >= windowsLevel {
+			r.Windows = prof.Windows
+			r.WindowsReason = tr(prof.Reason)
+			windowsLevel = level
+		}
+	}
+
+	return r
+}
@@ -19,20 +19,20 @@ type Circuit struct {
 	SensorData  Device           `json:"sensorData"`
 }
 
-// Status returns a human readable status message of the circuit.
+// Status returns a human readable status message of the circuit, translated into the language selected with SetLanguage.
 func (c Circuit) Status() string {
 	if !c.SensorData.State.Reachable {
-		return "unreachable"
+		return tr("unreachable")
 	}
 
-	status := fmt.Sprintf("moisture %d", c.SensorData.State.Moisture)
+	status := fmt.Sprintf(tr("moisture %d"), c.SensorData.State.Moisture)
 	switch {
 	case c.State.Irrigation:
-		status += "; irrigation active"
+		status += "; " + tr("irrigation active")
 	case c.SensorData.State.IrrigationNecessary:
-		status += "; very dry"
+		status += "; " + tr("very dry")
 	case c.SensorData.State.IrrigationPossible:
-		status += "; dry"
+		status += "; " + tr("dry")
 	}
 
 	return status
@@ -51,8 +51,8 @@ type Valve struct {
 
 type CircuitParams struct {
 	AutomaticMode           bool     `json:"automaticMode"`
-	BorderBottom            string   `json:"borderBottom"`
-	BorderTop               string   `json:"borderTop"`
+	BorderBottom            Percent  `json:"borderBottom"`
+	BorderTop               Percent  `json:"borderTop"`
 	ConsiderCharge          bool     `json:"considerCharge"`
 	ConsiderMower           bool     `json:"considerMower"`
 	ConsiderWeather         bool     `json:"considerWeather"`
@@ -72,6 +72,11 @@ type CircuitParams struct {
 	ValveStaggering         bool     `json:"valveStaggering"`
 }
 
+// Schedule returns the irrigation windows of all days, i.e. Day0 to Day6.
+func (p CircuitParams) Schedule() [7]string {
+	return [7]string{p.Day0, p.Day1, p.Day2, p.Day3, p.Day4, p.Day5, p.Day6}
+}
+
 type CircuitState struct {
 	AutomaticMode        bool
 	ExternBlock          bool
@@ -143,14 +148,14 @@ func (s SoilType) String() string {
 		SoilType_Unknown:    "unknown",
 	}
 	if name, ok := names[s]; ok {
-		return name
+		return tr(name)
 	}
 	return fmt.Sprintf("SoilType#%d", s)
 }
 
 // Areas returns status information for all irrigation areas,
-// called "circuits" by the MIYO Cube's API.
-func (c *Conn) Areas(ctx context.Context) ([]Circuit, error) {
+// called "circuits" by the MIYO Cube's API, ordered by name and ID.
+func (c *Conn) Areas(ctx context.Context) (AreaList, error) {
 	url := "http://" + c.host + "/api/circuit/all?apiKey=" + c.apiKey
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -171,10 +176,11 @@ func (c *Conn) Areas(ctx context.Context) ([]Circuit, error) {
 		return nil, fmt.Errorf("/api/circuit/all: %s", car.Error)
 	}
 
-	var ret []Circuit
+	var ret AreaList
 	for _, c := range car.Params.Circuits {
 		ret = append(ret, c)
 	}
+	sortAreas(ret)
 
 	return ret, nil
 }
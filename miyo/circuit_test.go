@@ -31,8 +31,8 @@ func TestCircuitAllResponse(t *testing.T) {
 					Name: "Rasen",
 					Params: CircuitParams{
 						AutomaticMode:   true,
-						BorderBottom:    "40",
-						BorderTop:       "60",
+						BorderBottom:    40,
+						BorderTop:       60,
 						ConsiderCharge:  true,
 						ConsiderWeather: true,
 						Day0:            "06:30-09:00;19:00-22:00",
@@ -94,8 +94,8 @@ func TestCircuitAllResponse(t *testing.T) {
 					Name: "Rosen",
 					Params: CircuitParams{
 						AutomaticMode:   true,
-						BorderBottom:    "50",
-						BorderTop:       "70",
+						BorderBottom:    50,
+						BorderTop:       70,
 						ConsiderCharge:  true,
 						ConsiderWeather: true,
 						Day0:            "20:00-22:00",
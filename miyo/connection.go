@@ -7,6 +7,7 @@ import (
 	"log"
 	"net/url"
 	"strings"
+	"time"
 
 	ssdp "github.com/koron/go-ssdp"
 )
@@ -16,12 +17,33 @@ import (
 type Conn struct {
 	host   string
 	apiKey string
+	loc    *time.Location
+}
+
+// Option configures a Conn.
+type Option func(*Conn)
+
+// WithLocation sets the time zone of the MIYO Cube. Schedule windows (Day0 to Day6) are wall clock times in this
+// time zone, so it is used for the time of snapshots. The default, and nil, is time.Local.
+func WithLocation(loc *time.Location) Option {
+	return func(c *Conn) {
+		c.loc = loc
+	}
+}
+
+// LoadLocation returns the time zone with the given name, e.g. "Europe/Berlin", for use with WithLocation. If
+// name is "", it returns time.Local.
+func LoadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.Local, nil
+	}
+	return time.LoadLocation(name)
 }
 
 // Connect returns an object representing a MIYO Cube.
 // If host is "", it uses FindCube() to discover the MIYO Cube with UPnP.
 // If APIKey is "", it uses APIKey() to request a new API key.
-func Connect(ctx context.Context, host, apiKey string) (*Conn, error) {
+func Connect(ctx context.Context, host, apiKey string, opts ...Option) (*Conn, error) {
 	if host == "" {
 		var err error
 		host, err = FindCube(ctx)
@@ -40,10 +62,22 @@ func Connect(ctx context.Context, host, apiKey string) (*Conn, error) {
 		log.Printf("New MIYO API key: %q", apiKey)
 	}
 
-	return &Conn{
+	c := &Conn{
 		host:   host,
 		apiKey: apiKey,
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// Location returns the time zone of the MIYO Cube, see WithLocation.
+func (c *Conn) Location() *time.Location {
+	if c.loc == nil {
+		return time.Local
+	}
+	return c.loc
 }
 
 // FindCube uses UPnP to discover a MIYO Cube on the local network and returns its address or hostname.
@@ -0,0 +1,109 @@
+package miyo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+type apiResponse struct {
+	ID     int    `json:"id"`
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
+// SetValve opens or closes the valve referred to by ref. If ref has no channel, the MIYO Cube picks the channel.
+func (c *Conn) SetValve(ctx context.Context, ref DeviceRef, open bool) error {
+	params := url.Values{}
+	params.Set("deviceId", ref.ID)
+	if ref.Channel != 0 {
+		params.Set("channel", strconv.Itoa(ref.Channel))
+	}
+	params.Set("stateType", "openValve")
+	params.Set("value", strconv.FormatBool(open))
+
+	return c.call(ctx, "/api/device/setState", params)
+}
+
+// SetIrrigation starts or stops the irrigation of the circuit with the given ID.
+func (c *Conn) SetIrrigation(ctx context.Context, circuitID string, irrigate bool) error {
+	params := url.Values{}
+	params.Set("circuitId", circuitID)
+	params.Set("stateType", "irrigation")
+	params.Set("value", strconv.FormatBool(irrigate))
+
+	return c.call(ctx, "/api/circuit/setState", params)
+}
+
+// SetAutomaticMode enables or disables the automatic irrigation of the circuit with the given ID.
+func (c *Conn) SetAutomaticMode(ctx context.Context, circuitID string, enabled bool) error {
+	params := url.Values{}
+	params.Set("circuitId", circuitID)
+	params.Set("stateType", "automaticMode")
+	params.Set("value", strconv.FormatBool(enabled))
+
+	return c.call(ctx, "/api/circuit/setState", params)
+}
+
+// SetSchedule sets the irrigation windows of one day of the circuit's schedule.
+// day is the index of the day, i.e. 0 sets CircuitParams.Day0.
+// windows has the same format as CircuitParams.Day0, e.g. "06:30-09:00;19:00-22:00".
+func (c *Conn) SetSchedule(ctx context.Context, circuitID string, day int, windows string) error {
+	if day < 0 || day > 6 {
+		return fmt.Errorf("invalid day %d", day)
+	}
+
+	params := url.Values{}
+	params.Set("circuitId", circuitID)
+	params.Set("day"+strconv.Itoa(day), windows)
+
+	return c.call(ctx, "/api/circuit/setParams", params)
+}
+
+// SetThresholds sets the moisture bounds of the circuit with the given ID. It returns an error without contacting
+// the MIYO Cube if the bounds are invalid, see CircuitParams.ValidateBounds.
+func (c *Conn) SetThresholds(ctx context.Context, circuitID string, bottom, top Percent) error {
+	p := CircuitParams{BorderBottom: bottom, BorderTop: top}
+	if err := p.ValidateBounds(); err != nil {
+		return err
+	}
+
+	params := url.Values{}
+	params.Set("circuitId", circuitID)
+	params.Set("borderBottom", strconv.Itoa(int(bottom)))
+	params.Set("borderTop", strconv.Itoa(int(top)))
+
+	return c.call(ctx, "/api/circuit/setParams", params)
+}
+
+// call sends a request to the given API endpoint and checks the status of the response.
+// The API key is added to params automatically.
+func (c *Conn) call(ctx context.Context, endpoint string, params url.Values) error {
+	params.Set("apiKey", c.apiKey)
+
+	url := "http://" + c.host + endpoint + "?" + params.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	var ar apiResponse
+	if err := json.NewDecoder(res.Body).Decode(&ar); err != nil {
+		return err
+	}
+
+	if ar.Status != "success" {
+		return fmt.Errorf("%s: %s", endpoint, ar.Error)
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package demand
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/octo/miyo-go/miyo"
+	"github.com/octo/miyo-go/miyo/history"
+)
+
+func TestRadiation(t *testing.T) {
+	// FAO-56, example 8: 20°S on 3 September.
+	got := Radiation(-20, time.Date(2022, 9, 3, 0, 0, 0, 0, time.UTC))
+	if want := 32.2; math.Abs(got-want) > 0.1 {
+		t.Errorf("Radiation() = %.2f, want %.1f", got, want)
+	}
+}
+
+func TestHargreaves(t *testing.T) {
+	// 0.0023 * (20 + 17.8) * sqrt(10) * 0.408 * 40
+	got := Hargreaves(15, 25, 40)
+	if want := 4.487; math.Abs(got-want) > 0.001 {
+		t.Errorf("Hargreaves() = %.3f, want %.3f", got, want)
+	}
+}
+
+func TestDayFromSamples(t *testing.T) {
+	date := time.Date(2022, 6, 21, 0, 0, 0, 0, time.UTC)
+	temps := []history.Sample{{Value: 14}, {Value: 27}, {Value: 21}}
+	brightness := []history.Sample{{Value: 10}, {Value: 30}}
+
+	got, ok := DayFromSamples(date, temps, brightness)
+	if !ok {
+		t.Fatal("DayFromSamples() = !ok")
+	}
+	want := Day{Date: date, TMin: 14, TMax: 27, Brightness: 20}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("DayFromSamples() differs (-want/+got):\n%s", diff)
+	}
+
+	if _, ok := DayFromSamples(date, nil, brightness); ok {
+		t.Error("DayFromSamples(no temperature) = ok")
+	}
+}
+
+func TestRecommend(t *testing.T) {
+	m := DefaultModel(48)
+	day := Day{
+		Date: time.Date(2022, 6, 21, 0, 0, 0, 0, time.UTC),
+		TMin: 14,
+		TMax: 28,
+	}
+
+	circuit := func(plant, location, irrigation int, soil miyo.SoilType, moisture int) miyo.Circuit {
+		return miyo.Circuit{
+			Name: "Rosen",
+			Params: miyo.CircuitParams{
+				BorderBottom:   40,
+				BorderTop:      60,
+				PlantType:      plant,
+				LocationType:   location,
+				IrrigationType: irrigation,
+				SoilType:       soil,
+			},
+			SensorData: miyo.Device{State: miyo.DeviceState{Reachable: true, Moisture: moisture}},
+		}
+	}
+
+	et0 := Hargreaves(day.TMin, day.TMax, Radiation(48, day.Date))
+
+	sunnyLawn := m.Recommend(circuit(0, 0, 0, miyo.SoilType_Loamy, 45), day)
+	if got, want := sunnyLawn.ETc, et0*0.8; math.Abs(got-want) > 1e-9 {
+		t.Errorf("ETc = %.2f, want %.2f", got, want)
+	}
+	wantDuration := time.Duration(math.Round(et0*0.8/0.9/10*60)) * time.Minute
+	if sunnyLawn.Duration != wantDuration {
+		t.Errorf("Duration = %v, want %v", sunnyLawn.Duration, wantDuration)
+	}
+
+	shaded := m.Recommend(circuit(0, 2, 0, miyo.SoilType_Loamy, 45), day)
+	if shaded.Duration >= sunnyLawn.Duration {
+		t.Errorf("shaded Duration = %v, want less than %v", shaded.Duration, sunnyLawn.Duration)
+	}
+
+	sandyDrip := m.Recommend(circuit(0, 0, 1, miyo.SoilType_Sandy, 45), day)
+	if sandyDrip.Duration <= sunnyLawn.Duration {
+		t.Errorf("sandy drip Duration = %v, want more than %v", sandyDrip.Duration, sunnyLawn.Duration)
+	}
+
+	wet := m.Recommend(circuit(0, 0, 0, miyo.SoilType_Loamy, 65), day)
+	if wet.Duration != 0 || wet.Reason == "" {
+		t.Errorf("Recommend(wet) = %v, want no irrigation", wet)
+	}
+
+	defer miyo.SetLanguage(miyo.CurrentLanguage())
+	miyo.SetLanguage("de")
+	wet = m.Recommend(circuit(0, 0, 0, miyo.SoilType_Loamy, 65), day)
+	wet.Circuit.Name = "Rasen"
+	if got, want := wet.String(), "Rasen: keine Bewässerung (Feuchte 65 auf oder über der Obergrenze 60)"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,85 @@
+// Package demand estimates the daily water demand of irrigation areas and recommends irrigation durations.
+//
+// The reference evapotranspiration (ET0) is estimated with the Hargreaves equation as described in FAO
+// Irrigation and Drainage Paper 56 ("FAO-56"), chapter 3, from the minimum and maximum temperature reported by an
+// area's sensor. The demand of an area (ETc) is ET0 multiplied by a crop coefficient for its plant type and a
+// factor for its location.
+package demand
+
+import (
+	"math"
+	"time"
+
+	"github.com/octo/miyo-go/miyo/history"
+)
+
+// solarConstant in MJ m⁻² min⁻¹ (FAO-56, eq. 21).
+const solarConstant = 0.0820
+
+// Radiation returns the extraterrestrial radiation Ra in MJ m⁻² day⁻¹ for the given latitude in degrees
+// (negative on the southern hemisphere) and day (FAO-56, eq. 21-25).
+func Radiation(latitude float64, day time.Time) float64 {
+	j := float64(day.YearDay())
+	phi := latitude * math.Pi / 180
+
+	dr := 1 + 0.033*math.Cos(2*math.Pi/365*j)
+	delta := 0.409 * math.Sin(2*math.Pi/365*j-1.39)
+	ws := math.Acos(math.Max(-1, math.Min(1, -math.Tan(phi)*math.Tan(delta))))
+
+	return 24 * 60 / math.Pi * solarConstant * dr *
+		(ws*math.Sin(phi)*math.Sin(delta) + math.Cos(phi)*math.Cos(delta)*math.Sin(ws))
+}
+
+// Hargreaves returns the reference evapotranspiration ET0 in mm day⁻¹ from the daily minimum and maximum
+// temperature in °C and the extraterrestrial radiation Ra in MJ m⁻² day⁻¹ (FAO-56, eq. 52).
+func Hargreaves(tmin, tmax, ra float64) float64 {
+	if tmax < tmin {
+		tmin, tmax = tmax, tmin
+	}
+	tmean := (tmin + tmax) / 2
+	// 0.408 converts MJ m⁻² day⁻¹ into mm day⁻¹ of evaporated water.
+	et0 := 0.0023 * (tmean + 17.8) * math.Sqrt(tmax-tmin) * 0.408 * ra
+	return math.Max(0, et0)
+}
+
+// HargreavesRadiation returns the reference evapotranspiration ET0 in mm day⁻¹ from the mean temperature in °C
+// and the measured solar radiation Rs in MJ m⁻² day⁻¹ (Hargreaves and Samani, 1985).
+func HargreavesRadiation(tmean, rs float64) float64 {
+	return math.Max(0, 0.0135*(tmean+17.8)*0.408*rs)
+}
+
+// Day holds the sensor readings of one day.
+type Day struct {
+	Date time.Time
+	// TMin and TMax are the minimum and maximum temperature in °C.
+	TMin, TMax float64
+	// Brightness is the mean brightness reported by the sensor.
+	Brightness float64
+}
+
+// DayFromSamples summarizes the temperature and brightness samples of a sensor. ok is false if there are no
+// temperature samples.
+func DayFromSamples(date time.Time, temperature, brightness []history.Sample) (d Day, ok bool) {
+	if len(temperature) == 0 {
+		return Day{}, false
+	}
+
+	d = Day{
+		Date: date,
+		TMin: math.Inf(1),
+		TMax: math.Inf(-1),
+	}
+	for _, s := range temperature {
+		d.TMin = math.Min(d.TMin, s.Value)
+		d.TMax = math.Max(d.TMax, s.Value)
+	}
+
+	for _, s := range brightness {
+		d.Brightness += s.Value
+	}
+	if len(brightness) > 0 {
+		d.Brightness /= float64(len(brightness))
+	}
+
+	return d, true
+}
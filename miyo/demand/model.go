@@ -0,0 +1,163 @@
+package demand
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/octo/miyo-go/miyo"
+)
+
+// Model holds the site specific parameters of the estimation.
+//
+// The MIYO API doesn't document the meaning of the numeric PlantType, LocationType and IrrigationType codes, so
+// the maps keyed by them are configurable. The defaults assume the order used by the MIYO app.
+type Model struct {
+	// Latitude of the garden in degrees, negative on the southern hemisphere.
+	Latitude float64 `json:"latitude"`
+	// RadiationPerBrightness converts the sensor's mean daily brightness into solar radiation in MJ m⁻² day⁻¹.
+	// The sensors aren't calibrated, so this is zero by default and radiation is estimated from the
+	// temperature range instead.
+	RadiationPerBrightness float64 `json:"radiationPerBrightness"`
+	// CropCoefficients holds the crop coefficient Kc, keyed by CircuitParams.PlantType.
+	CropCoefficients map[int]float64 `json:"cropCoefficients"`
+	// LocationFactors reduces the demand of shaded areas, keyed by CircuitParams.LocationType.
+	LocationFactors map[int]float64 `json:"locationFactors"`
+	// SoilEfficiency is the fraction of applied water available to plants, keyed by CircuitParams.SoilType.
+	// Sandy soils lose more water to deep percolation.
+	SoilEfficiency map[miyo.SoilType]float64 `json:"soilEfficiency"`
+	// ApplicationRates is the amount of water applied in mm per hour, keyed by CircuitParams.IrrigationType.
+	ApplicationRates map[int]float64 `json:"applicationRates"`
+}
+
+// DefaultModel returns a model with typical coefficients for the given latitude.
+func DefaultModel(latitude float64) Model {
+	return Model{
+		Latitude: latitude,
+		CropCoefficients: map[int]float64{
+			0: 0.8, // lawn
+			1: 0.9, // flower bed
+			2: 0.7, // shrubs and roses
+			3: 1.0, // vegetables
+			4: 0.6, // trees
+		},
+		LocationFactors: map[int]float64{
+			0: 1.0, // sunny
+			1: 0.8, // partially shaded
+			2: 0.6, // shaded
+		},
+		SoilEfficiency: map[miyo.SoilType]float64{
+			miyo.SoilType_Loamy:      0.9,
+			miyo.SoilType_Sandy:      0.7,
+			miyo.SoilType_LoamySandy: 0.8,
+			miyo.SoilType_Unknown:    0.8,
+		},
+		ApplicationRates: map[int]float64{
+			0: 10, // sprinkler
+			1: 4,  // drip
+		},
+	}
+}
+
+func lookup(m map[int]float64, key int, def float64) float64 {
+	if v, ok := m[key]; ok {
+		return v
+	}
+	return def
+}
+
+// Demand is the estimated water demand of a circuit on one day.
+type Demand struct {
+	Circuit miyo.Circuit
+	// ET0 is the reference evapotranspiration in mm.
+	ET0 float64
+	// ETc is the demand of the circuit's plants in mm, i.e. liters per square meter.
+	ETc float64
+}
+
+// Estimate returns the water demand of circuit c on day d.
+func (m Model) Estimate(c miyo.Circuit, d Day) Demand {
+	var et0 float64
+	if m.RadiationPerBrightness > 0 && d.Brightness > 0 {
+		et0 = HargreavesRadiation((d.TMin+d.TMax)/2, d.Brightness*m.RadiationPerBrightness)
+	} else {
+		et0 = Hargreaves(d.TMin, d.TMax, Radiation(m.Latitude, d.Date))
+	}
+
+	kc := lookup(m.CropCoefficients, c.Params.PlantType, 1)
+	kl := lookup(m.LocationFactors, c.Params.LocationType, 1)
+
+	return Demand{
+		Circuit: c,
+		ET0:     et0,
+		ETc:     et0 * kc * kl,
+	}
+}
+
+// Recommendation is the recommended irrigation of a circuit.
+type Recommendation struct {
+	Demand
+	// Water is the amount of water to apply in mm, accounting for the soil's efficiency.
+	Water    float64
+	Duration time.Duration
+	// Reason explains a zero duration, translated into the current language.
+	Reason string
+}
+
+func init() {
+	miyo.RegisterCatalog(domain, "de", miyo.Catalog{
+		"%s: no irrigation (%s)":                          "%s: keine Bewässerung (%s)",
+		"%s: irrigate %v (demand %.1f mm, apply %.1f mm)": "%s: %v bewässern (Bedarf %.1f mm, Gabe %.1f mm)",
+		"moisture %d at or above upper bound %d":          "Feuchte %d auf oder über der Obergrenze %d",
+		"no application rate for irrigation type %d":      "keine Bewässerungsrate für Bewässerungsart %d",
+		"demand too low":                                  "Bedarf zu gering",
+	})
+}
+
+// domain is the catalog domain of this package's messages.
+const domain = "demand"
+
+// tr translates msg of this package.
+func tr(msg string) string {
+	return miyo.Translate(domain, msg)
+}
+
+// String formats the recommendation, e.g. "Rasen: irrigate 25m0s (demand 4.1 mm, apply 5.1 mm)", translated into
+// the current language.
+func (r Recommendation) String() string {
+	if r.Duration == 0 {
+		return fmt.Sprintf(tr("%s: no irrigation (%s)"), r.Circuit.Name, r.Reason)
+	}
+	return fmt.Sprintf(tr("%s: irrigate %v (demand %.1f mm, apply %.1f mm)"), r.Circuit.Name, r.Duration, r.ETc, r.Water)
+}
+
+// Recommend returns the irrigation duration that replaces the circuit's demand on day d. No irrigation is
+// recommended if the sensor reports moisture at or above the circuit's upper bound.
+func (m Model) Recommend(c miyo.Circuit, d Day) Recommendation {
+	r := Recommendation{Demand: m.Estimate(c, d)}
+
+	s := c.SensorData.State
+	if c.Params.ValidateBounds() == nil && s.Reachable && c.Params.DistanceToTarget(s.Moisture) <= 0 {
+		r.Reason = fmt.Sprintf(tr("moisture %d at or above upper bound %d"), s.Moisture, c.Params.BorderTop)
+		return r
+	}
+
+	efficiency := 0.8
+	if e, ok := m.SoilEfficiency[c.Params.SoilType]; ok {
+		efficiency = e
+	}
+	r.Water = r.ETc / efficiency
+
+	rate := lookup(m.ApplicationRates, c.Params.IrrigationType, 0)
+	if rate <= 0 {
+		r.Reason = fmt.Sprintf(tr("no application rate for irrigation type %d"), c.Params.IrrigationType)
+		return r
+	}
+
+	hours := r.Water / rate
+	r.Duration = time.Duration(math.Round(hours*60)) * time.Minute
+	if r.Duration == 0 {
+		r.Reason = tr("demand too low")
+	}
+	return r
+}
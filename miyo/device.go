@@ -6,6 +6,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
+	"strings"
 )
 
 type deviceAllResponse struct {
@@ -25,28 +27,54 @@ type Device struct {
 	IPv6       string      `json:"ipv6"`
 	LastUpdate int         `json:"lastUpdate"`
 	State      DeviceState `json:"stateTypes"`
+	// Ref is the key of the device in the API's device list. It is set by Conn.Devices.
+	Ref DeviceRef `json:"ref"`
 }
 
+// Status returns a human readable status message of the device, translated into the language selected with SetLanguage.
 func (d Device) Status() string {
 	if !d.State.Reachable {
-		return "unreachable"
+		return tr("unreachable")
 	}
 
-	switch d.Type {
-	case "valve":
+	switch d.DeviceType() {
+	case DeviceType_Valve:
 		if d.State.ValveStatus {
-			return "open"
+			return tr("open")
 		}
-		return "closed"
-	case "moistureOutdoor":
-		return fmt.Sprintf("moisture %d", d.State.Moisture)
+		return tr("closed")
+	case DeviceType_MoistureSensor:
+		return fmt.Sprintf(tr("moisture %d"), d.State.Moisture)
 	default:
-		return "unknown type"
+		return tr("unknown type")
 	}
 }
 
-// Devices returns status information for all devices.
-func (c *Conn) Devices(ctx context.Context) ([]Device, error) {
+// Describe names the device in messages, e.g. "valve e00cc4d9 (Rosen)", translated into the language selected with
+// SetLanguage. area is the name of the area using the device; it is omitted if empty.
+func (d Device) Describe(area string) string {
+	role := "device"
+	switch d.DeviceType() {
+	case DeviceType_Valve:
+		role = "valve"
+	case DeviceType_MoistureSensor:
+		role = "sensor"
+	}
+
+	id := strings.Trim(d.ID, "{}")
+	if i := strings.Index(id, "-"); i != -1 {
+		id = id[:i]
+	}
+
+	name := tr(role) + " " + id
+	if area != "" {
+		name += " (" + area + ")"
+	}
+	return name
+}
+
+// Devices returns status information for all devices, ordered by ID and channel.
+func (c *Conn) Devices(ctx context.Context) (DeviceList, error) {
 	url := "http://" + c.host + "/api/device/all?apiKey=" + c.apiKey
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -63,12 +91,29 @@ func (c *Conn) Devices(ctx context.Context) ([]Device, error) {
 		return nil, err
 	}
 
-	var devs []Device
-	for _, d := range dar.Params.Devices {
+	return dar.devices(), nil
+}
+
+// devices returns the devices of the response, ordered by ID and channel.
+func (dar deviceAllResponse) devices() DeviceList {
+	var devs DeviceList
+	for key, d := range dar.Params.Devices {
+		ref, err := ParseDeviceRef(key)
+		if err != nil {
+			ref = DeviceRef{ID: d.ID, Channel: d.Channel}
+		}
+		d.Ref = ref
 		devs = append(devs, d)
 	}
 
-	return devs, nil
+	sort.Slice(devs, func(i, j int) bool {
+		if devs[i].Ref.ID != devs[j].Ref.ID {
+			return devs[i].Ref.ID < devs[j].Ref.ID
+		}
+		return devs[i].Ref.Channel < devs[j].Ref.Channel
+	})
+
+	return devs
 }
 
 // DeviceState holds the state of a device.
@@ -90,3 +90,45 @@ func TestDeviceAllResponse(t *testing.T) {
 		t.Errorf("parsed response differs (-want/+got):\n%s", diff)
 	}
 }
+
+func TestDeviceAllResponseOrder(t *testing.T) {
+	var dar deviceAllResponse
+	dar.Params.Devices = map[string]Device{
+		"{b};1":  {ID: "{b}", Channel: 1},
+		"{a};10": {ID: "{a}", Channel: 10},
+		"{a};2":  {ID: "{a}", Channel: 2},
+	}
+
+	var got []string
+	for _, d := range dar.devices() {
+		got = append(got, d.Ref.String())
+	}
+	want := []string{"{a};2", "{a};10", "{b};1"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("devices() order differs (-want/+got):\n%s", diff)
+	}
+}
+
+func TestDeviceDescribe(t *testing.T) {
+	defer SetLanguage(CurrentLanguage())
+
+	valve := Device{ID: "{e00cc4d9-c0fa-41c3-aa8e-7ba088dc0f77}", Type: "valve"}
+	sensor := Device{ID: "{0d0b7ac4}", Type: "moistureOutdoor"}
+	cases := []struct {
+		lang string
+		d    Device
+		area string
+		want string
+	}{
+		{"en", valve, "Rosen", "valve e00cc4d9 (Rosen)"},
+		{"en", sensor, "", "sensor 0d0b7ac4"},
+		{"en", Device{ID: "{f00}", Type: "gateway"}, "", "device f00"},
+		{"de", valve, "Rosen", "Ventil e00cc4d9 (Rosen)"},
+	}
+	for _, tc := range cases {
+		SetLanguage(tc.lang)
+		if got := tc.d.Describe(tc.area); got != tc.want {
+			t.Errorf("[%s] Describe(%q) = %q, want %q", tc.lang, tc.area, got, tc.want)
+		}
+	}
+}
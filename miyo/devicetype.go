@@ -0,0 +1,210 @@
+package miyo
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DeviceType is the type of a device, derived from Device.Type.
+type DeviceType int
+
+const (
+	DeviceType_Unknown DeviceType = iota
+	DeviceType_Valve
+	DeviceType_MoistureSensor
+)
+
+// deviceTypes holds the registered device types.
+var deviceTypes = struct {
+	sync.RWMutex
+	// byID maps the "deviceTypeId" returned by the API to the device type.
+	byID  map[string]DeviceType
+	names map[DeviceType]string
+	views map[DeviceType]func(Device) interface{}
+}{
+	byID: map[string]DeviceType{
+		"valve":           DeviceType_Valve,
+		"moistureOutdoor": DeviceType_MoistureSensor,
+	},
+	names: map[DeviceType]string{
+		DeviceType_Unknown:        "unknown",
+		DeviceType_Valve:          "valve",
+		DeviceType_MoistureSensor: "moisture_sensor",
+	},
+	views: map[DeviceType]func(Device) interface{}{
+		DeviceType_Valve:          func(d Device) interface{} { return ValveDevice{d} },
+		DeviceType_MoistureSensor: func(d Device) interface{} { return MoistureSensor{d} },
+	},
+}
+
+func (t DeviceType) String() string {
+	deviceTypes.RLock()
+	defer deviceTypes.RUnlock()
+
+	if name, ok := deviceTypes.names[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("DeviceType#%d", t)
+}
+
+// RegisterDeviceType registers a device type not known to this package. typeID is the "deviceTypeId" returned
+// by the API, name is returned by DeviceType.String(), and view, if not nil, returns the typed view of a device
+// returned by Device.View(). It panics if typeID is already registered.
+func RegisterDeviceType(typeID, name string, view func(Device) interface{}) DeviceType {
+	deviceTypes.Lock()
+	defer deviceTypes.Unlock()
+
+	if _, ok := deviceTypes.byID[typeID]; ok {
+		panic(fmt.Sprintf("device type %q registered twice", typeID))
+	}
+
+	t := DeviceType(len(deviceTypes.names))
+	deviceTypes.byID[typeID] = t
+	deviceTypes.names[t] = name
+	if view != nil {
+		deviceTypes.views[t] = view
+	}
+	return t
+}
+
+// DeviceType returns the type of the device.
+func (d Device) DeviceType() DeviceType {
+	deviceTypes.RLock()
+	defer deviceTypes.RUnlock()
+
+	return deviceTypes.byID[d.Type]
+}
+
+// View returns a typed view of the device, i.e. a ValveDevice, a MoistureSensor or the view of a registered
+// device type. Devices of unknown type are returned as is.
+func (d Device) View() interface{} {
+	t := d.DeviceType()
+
+	deviceTypes.RLock()
+	view, ok := deviceTypes.views[t]
+	deviceTypes.RUnlock()
+
+	if !ok {
+		return d
+	}
+	return view(d)
+}
+
+// DeviceList is a list of devices as returned by Conn.Devices.
+type DeviceList []Device
+
+// OfType returns the devices of type t.
+func (l DeviceList) OfType(t DeviceType) DeviceList {
+	var ret DeviceList
+	for _, d := range l {
+		if d.DeviceType() == t {
+			ret = append(ret, d)
+		}
+	}
+	return ret
+}
+
+// Valves returns all valves.
+func (l DeviceList) Valves() []ValveDevice {
+	var ret []ValveDevice
+	for _, d := range l.OfType(DeviceType_Valve) {
+		ret = append(ret, ValveDevice{d})
+	}
+	return ret
+}
+
+// MoistureSensors returns all moisture sensors.
+func (l DeviceList) MoistureSensors() []MoistureSensor {
+	var ret []MoistureSensor
+	for _, d := range l.OfType(DeviceType_MoistureSensor) {
+		ret = append(ret, MoistureSensor{d})
+	}
+	return ret
+}
+
+// ValveDevice is a read-only view of a device of type DeviceType_Valve. It only provides the values valves report.
+// (Valve is a valve's entry in a Circuit.)
+type ValveDevice struct {
+	d Device
+}
+
+// AsValve returns the valve view of d. ok is false if d is not a valve.
+func AsValve(d Device) (v ValveDevice, ok bool) {
+	if d.DeviceType() != DeviceType_Valve {
+		return ValveDevice{}, false
+	}
+	return ValveDevice{d}, true
+}
+
+func (v ValveDevice) Device() Device                    { return v.d }
+func (v ValveDevice) ID() string                        { return v.d.ID }
+func (v ValveDevice) Reachable() bool                   { return v.d.State.Reachable }
+func (v ValveDevice) Updated() time.Time                { return v.d.Updated() }
+func (v ValveDevice) RSSI() int                         { return v.d.State.RSSI }
+func (v ValveDevice) LowPower() bool                    { return v.d.State.LowPower }
+func (v ValveDevice) Charging() bool                    { return v.d.State.Charging }
+func (v ValveDevice) SolarVoltage() int                 { return v.d.State.SolarVoltage }
+func (v ValveDevice) IrrigationStarted() time.Time      { return v.d.State.IrrigationStarted() }
+func (v ValveDevice) IrrigationEnded() time.Time        { return v.d.State.IrrigationEnded() }
+func (v ValveDevice) IrrigationDuration() time.Duration { return v.d.State.IrrigationDuration() }
+
+// Open reports whether the valve is open.
+func (v ValveDevice) Open() bool { return v.d.State.ValveStatus }
+
+// Opening reports whether the valve is to be opened.
+func (v ValveDevice) Opening() bool { return v.d.State.OpenValve }
+
+// Closing reports whether the valve is to be closed.
+func (v ValveDevice) Closing() bool { return v.d.State.ValveInitialClose }
+
+// MarshalJSON encodes the underlying device.
+func (v ValveDevice) MarshalJSON() ([]byte, error) { return json.Marshal(v.d) }
+
+// MoistureSensor is a read-only view of a device of type DeviceType_MoistureSensor. It only provides the values
+// sensors report.
+type MoistureSensor struct {
+	d Device
+}
+
+// AsMoistureSensor returns the sensor view of d. ok is false if d is not a moisture sensor.
+func AsMoistureSensor(d Device) (s MoistureSensor, ok bool) {
+	if d.DeviceType() != DeviceType_MoistureSensor {
+		return MoistureSensor{}, false
+	}
+	return MoistureSensor{d}, true
+}
+
+func (s MoistureSensor) Device() Device     { return s.d }
+func (s MoistureSensor) ID() string         { return s.d.ID }
+func (s MoistureSensor) Reachable() bool    { return s.d.State.Reachable }
+func (s MoistureSensor) Updated() time.Time { return s.d.Updated() }
+func (s MoistureSensor) RSSI() int          { return s.d.State.RSSI }
+func (s MoistureSensor) LowPower() bool     { return s.d.State.LowPower }
+func (s MoistureSensor) Charging() bool     { return s.d.State.Charging }
+func (s MoistureSensor) SolarVoltage() int  { return s.d.State.SolarVoltage }
+
+// Moisture returns the soil moisture in percent.
+func (s MoistureSensor) Moisture() int { return s.d.State.Moisture }
+
+// Brightness returns the brightness in lux.
+func (s MoistureSensor) Brightness() int { return s.d.State.Brightness }
+
+// Temperature returns the temperature near the ground in °C.
+func (s MoistureSensor) Temperature() int { return s.d.State.Temperature }
+
+// TemperatureOffset returns the configured temperature offset.
+func (s MoistureSensor) TemperatureOffset() int { return s.d.State.TemperatureOffset }
+
+// Frequency returns the frequency of the moisture sensor.
+func (s MoistureSensor) Frequency() int { return s.d.State.Frequency }
+
+// IrrigationNecessary reports whether the soil is very dry.
+func (s MoistureSensor) IrrigationNecessary() bool { return s.d.State.IrrigationNecessary }
+
+// IrrigationPossible reports whether the soil is dry.
+func (s MoistureSensor) IrrigationPossible() bool { return s.d.State.IrrigationPossible }
+
+// MarshalJSON encodes the underlying device.
+func (s MoistureSensor) MarshalJSON() ([]byte, error) { return json.Marshal(s.d) }
@@ -0,0 +1,62 @@
+package miyo
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDeviceList(t *testing.T) {
+	devs := DeviceList{
+		{ID: "valve-a", Type: "valve", State: DeviceState{ValveStatus: true}},
+		{ID: "sensor-a", Type: "moistureOutdoor", State: DeviceState{Moisture: 42}},
+		{ID: "valve-b", Type: "valve"},
+		{ID: "mower-a", Type: "mower"},
+	}
+
+	var valves []string
+	for _, v := range devs.Valves() {
+		valves = append(valves, v.ID())
+	}
+	if diff := cmp.Diff([]string{"valve-a", "valve-b"}, valves); diff != "" {
+		t.Errorf("Valves() differs (-want/+got):\n%s", diff)
+	}
+
+	sensors := devs.MoistureSensors()
+	if len(sensors) != 1 || sensors[0].Moisture() != 42 {
+		t.Errorf("MoistureSensors() = %v, want one sensor with moisture 42", sensors)
+	}
+
+	if _, ok := AsValve(devs[1]); ok {
+		t.Error("AsValve(sensor) = ok")
+	}
+	if v, ok := devs[0].View().(ValveDevice); !ok || !v.Open() {
+		t.Errorf("View() = %#v, want open ValveDevice", devs[0].View())
+	}
+
+	if got := devs[3].DeviceType(); got != DeviceType_Unknown {
+		t.Errorf("DeviceType() = %v, want %v", got, DeviceType_Unknown)
+	}
+}
+
+func TestRegisterDeviceType(t *testing.T) {
+	type mower struct{ id string }
+
+	mowerType := RegisterDeviceType("testMower", "mower", func(d Device) interface{} {
+		return mower{d.ID}
+	})
+
+	d := Device{ID: "mower-a", Type: "testMower"}
+	if got := d.DeviceType(); got != mowerType {
+		t.Errorf("DeviceType() = %v, want %v", got, mowerType)
+	}
+	if got, want := mowerType.String(), "mower"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if diff := cmp.Diff(mower{"mower-a"}, d.View(), cmp.AllowUnexported(mower{})); diff != "" {
+		t.Errorf("View() differs (-want/+got):\n%s", diff)
+	}
+	if got := (DeviceList{d}).OfType(mowerType); len(got) != 1 {
+		t.Errorf("OfType(%v) = %v, want one device", mowerType, got)
+	}
+}
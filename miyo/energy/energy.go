@@ -0,0 +1,391 @@
+// Package energy estimates the energy balance of solar powered valves and sensors and predicts when they will
+// report low power.
+//
+// The devices don't report their battery level, only the time they charged per day, the solar voltage, and
+// whether they are charging or low on power. The analysis aggregates the recorded values per day and models the
+// battery as a state of charge between 0 and 1: without charging, a full battery lasts Model.Autonomy; charging
+// for Model.RequiredCharging minutes a day exactly covers the consumption. Whenever a device reported low power,
+// the state of charge is reset to Model.LowPowerLevel. The trend of the daily charging time, fitted by linear
+// regression, is extrapolated to predict when the state of charge drops to that level.
+package energy
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/octo/miyo-go/miyo"
+	"github.com/octo/miyo-go/miyo/history"
+)
+
+const day = 24 * time.Hour
+
+// Model describes the batteries and consumption of the devices.
+type Model struct {
+	// RequiredCharging is the charging time, in minutes per day, that covers the consumption of a device,
+	// keyed by device type. Valves use more energy than sensors because they drive a motor.
+	RequiredCharging map[miyo.DeviceType]float64
+	// Autonomy is the time a full battery lasts without charging.
+	Autonomy time.Duration
+	// LowPowerLevel is the state of charge, between 0 and 1, at which a device reports low power.
+	LowPowerLevel float64
+	// Horizon is the time up to which low power is predicted.
+	Horizon time.Duration
+}
+
+// DefaultModel is the model used by Analyzer if none is set.
+var DefaultModel = Model{
+	RequiredCharging: map[miyo.DeviceType]float64{
+		miyo.DeviceType_Valve:          60,
+		miyo.DeviceType_MoistureSensor: 20,
+		miyo.DeviceType_Unknown:        60,
+	},
+	Autonomy:      21 * day,
+	LowPowerLevel: 0.2,
+	Horizon:       60 * day,
+}
+
+func (m Model) required(t miyo.DeviceType) float64 {
+	if r, ok := m.RequiredCharging[t]; ok {
+		return r
+	}
+	return m.RequiredCharging[miyo.DeviceType_Unknown]
+}
+
+// Day holds the values of one device aggregated over one day.
+type Day struct {
+	Date time.Time `json:"date"`
+	// Charging is the charging time in minutes on this day, the maximum of DeviceState.ChargingDurationDay.
+	Charging float64 `json:"charging"`
+	// ChargingShare is the fraction of samples in which the device was charging.
+	ChargingShare float64 `json:"chargingShare"`
+	// SolarVoltage is the average solar voltage.
+	SolarVoltage float64 `json:"solarVoltage"`
+	// LowPower is true if the device reported low power on this day.
+	LowPower bool `json:"lowPower"`
+}
+
+// Days aggregates the recorded samples of one device per day in the time zone loc, usually the MIYO Cube's.
+// Days without any chargingDurationDay sample are omitted.
+func Days(loc *time.Location, chargingDuration, charging, solarVoltage, lowPower []history.Sample) []Day {
+	type agg struct {
+		sum [4]float64
+		n   [4]int
+	}
+
+	aggs := make(map[time.Time]*agg)
+	for i, samples := range [][]history.Sample{chargingDuration, charging, solarVoltage, lowPower} {
+		for _, s := range samples {
+			date := startOfDay(s.Time.In(loc))
+			a, ok := aggs[date]
+			if !ok {
+				a = &agg{}
+				aggs[date] = a
+			}
+			if i == 0 || i == 3 {
+				// chargingDurationDay is a running total of the day, so its maximum is the day's charging
+				// time. lowPower: any report counts.
+				a.sum[i] = math.Max(a.sum[i], s.Value)
+			} else {
+				a.sum[i] += s.Value
+			}
+			a.n[i]++
+		}
+	}
+
+	var ret []Day
+	for date, a := range aggs {
+		if a.n[0] == 0 {
+			continue
+		}
+		d := Day{
+			Date:     date,
+			Charging: a.sum[0],
+			LowPower: a.sum[3] > 0,
+		}
+		if a.n[1] > 0 {
+			d.ChargingShare = a.sum[1] / float64(a.n[1])
+		}
+		if a.n[2] > 0 {
+			d.SolarVoltage = a.sum[2] / float64(a.n[2])
+		}
+		ret = append(ret, d)
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Date.Before(ret[j].Date)
+	})
+	return ret
+}
+
+// startOfDay returns midnight of t's day in t's location.
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// Balance is the estimated energy balance of one device.
+type Balance struct {
+	Device miyo.Device `json:"device"`
+	// Name describes the device in messages, e.g. "valve e00cc4d9 (Rosen)".
+	Name string `json:"name"`
+	// Area is the name of the area using the device, if any.
+	Area string `json:"area,omitempty"`
+	// Days are the daily values the balance is based on, in chronological order.
+	Days []Day `json:"days"`
+	// Charging is the average charging time in minutes per day over the last week, and Required the charging
+	// time that covers the device's consumption.
+	Charging float64 `json:"charging"`
+	Required float64 `json:"required"`
+	// Surplus is Charging minus Required. It is negative if the battery drains.
+	Surplus float64 `json:"surplus"`
+	// Trend is the change of the daily charging time in minutes per day, e.g. -2 if the device charges two
+	// minutes less every day.
+	Trend float64 `json:"trend"`
+	// SolarVoltageTrend is the change of the average solar voltage per day.
+	SolarVoltageTrend float64 `json:"solarVoltageTrend"`
+	// Level is the estimated state of charge, between 0 and 1.
+	Level float64 `json:"level"`
+	// LowPower is true if the device currently reports low power.
+	LowPower bool `json:"lowPower"`
+	// LowPowerAt is the time the device is predicted to report low power. It is the current time if the device
+	// already does, and the zero time if it isn't expected within Model.Horizon.
+	LowPowerAt time.Time `json:"lowPowerAt"`
+	// LowPowerIn is the duration until LowPowerAt.
+	LowPowerIn time.Duration `json:"lowPowerIn"`
+}
+
+func init() {
+	miyo.RegisterCatalog(domain, "de", miyo.Catalog{
+		"%s reports low power":                                           "%s meldet einen schwachen Akku",
+		"%s will run low on power in ~%d days":                           "%s hat in ~%d Tagen einen schwachen Akku",
+		"%s has a stable energy balance":                                 "%s hat eine stabile Energiebilanz",
+		"%s: charging %.0f of %.0f min/day, trend %+.1f min/day per day": "%s: lädt %.0f von %.0f min/Tag, Trend %+.1f min/Tag pro Tag",
+	})
+}
+
+// domain is the catalog domain of this package's messages.
+const domain = "energy"
+
+// tr translates msg of this package.
+func tr(msg string) string {
+	return miyo.Translate(domain, msg)
+}
+
+// String formats the prediction, e.g. "valve e00cc4d9 (Rosen) will run low on power in ~9 days", translated
+// into the current language.
+func (b Balance) String() string {
+	switch {
+	case b.LowPower:
+		return fmt.Sprintf(tr("%s reports low power"), b.Name)
+	case b.LowPowerAt.IsZero():
+		return fmt.Sprintf(tr("%s has a stable energy balance"), b.Name)
+	default:
+		return fmt.Sprintf(tr("%s will run low on power in ~%d days"), b.Name, wholeDays(b.LowPowerIn))
+	}
+}
+
+// Summary formats the charging time and its trend, e.g. "valve e00cc4d9 (Rosen): charging 35 of 60 min/day,
+// trend -2.0 min/day per day", translated into the current language.
+func (b Balance) Summary() string {
+	return fmt.Sprintf(tr("%s: charging %.0f of %.0f min/day, trend %+.1f min/day per day"),
+		b.Name, b.Charging, b.Required, b.Trend)
+}
+
+// wholeDays returns d in whole days, at least one.
+func wholeDays(d time.Duration) int {
+	n := int(math.Round(d.Hours() / 24))
+	if n < 1 {
+		return 1
+	}
+	return n
+}
+
+// Analyze estimates the energy balance of d from its daily values in chronological order. area is the name of
+// the area using the device, or empty.
+func Analyze(d miyo.Device, area string, days []Day, now time.Time, m Model) (Balance, error) {
+	b := Balance{
+		Device:   d,
+		Name:     d.Describe(area),
+		Area:     area,
+		Days:     days,
+		Required: m.required(d.DeviceType()),
+		LowPower: d.State.LowPower,
+	}
+	if len(days) == 0 {
+		return Balance{}, fmt.Errorf("%s: no charging time recorded", b.Name)
+	}
+	if b.Required <= 0 || m.Autonomy <= 0 {
+		return Balance{}, fmt.Errorf("%s: invalid model: required charging %v, autonomy %v", b.Name, b.Required, m.Autonomy)
+	}
+
+	// The week are the seven calendar days before today, plus today if recorded.
+	weekStart := startOfDay(now).AddDate(0, 0, -7)
+	var week []Day
+	for _, dd := range days {
+		if !dd.Date.Before(weekStart) {
+			week = append(week, dd)
+		}
+	}
+	if len(week) == 0 {
+		week = days[len(days)-1:]
+	}
+	for _, dd := range week {
+		b.Charging += dd.Charging
+	}
+	b.Charging /= float64(len(week))
+	b.Surplus = b.Charging - b.Required
+
+	b.Trend = slope(days, func(dd Day) float64 { return dd.Charging })
+	b.SolarVoltageTrend = slope(days, func(dd Day) float64 { return dd.SolarVoltage })
+
+	// The battery is assumed to be full at the beginning of the recorded history.
+	b.Level = 1
+	for _, dd := range days {
+		b.Level = m.charge(b.Level, dd.Charging, b.Required)
+		if dd.LowPower {
+			b.Level = math.Min(b.Level, m.LowPowerLevel)
+		} else {
+			b.Level = math.Max(b.Level, m.LowPowerLevel)
+		}
+	}
+	if b.LowPower {
+		b.Level = math.Min(b.Level, m.LowPowerLevel)
+	}
+
+	if b.LowPower {
+		b.LowPowerAt = now
+		return b, nil
+	}
+
+	level := b.Level
+	for n := 1; time.Duration(n)*day <= m.Horizon; n++ {
+		charging := math.Max(0, b.Charging+b.Trend*float64(n))
+		level = m.charge(level, charging, b.Required)
+		if level <= m.LowPowerLevel {
+			b.LowPowerIn = time.Duration(n) * day
+			b.LowPowerAt = now.Add(b.LowPowerIn)
+			break
+		}
+	}
+
+	return b, nil
+}
+
+// charge returns the state of charge after one day of charging for the given minutes.
+func (m Model) charge(level, charging, required float64) float64 {
+	level += (charging/required - 1) * float64(day) / float64(m.Autonomy)
+	return math.Max(0, math.Min(1, level))
+}
+
+// slope fits a line to the values of days using linear regression and returns its slope per day.
+func slope(days []Day, value func(Day) float64) float64 {
+	if len(days) < 2 {
+		return 0
+	}
+
+	var n, sumX, sumY, sumXX, sumXY float64
+	for _, d := range days {
+		x := d.Date.Sub(days[0].Date).Hours() / 24
+		y := value(d)
+		n++
+		sumX += x
+		sumY += y
+		sumXX += x * x
+		sumXY += x * y
+	}
+
+	denom := n*sumXX - sumX*sumX
+	if denom == 0 {
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / denom
+}
+
+// Report is the energy balance of all devices.
+type Report struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+	// Devices are ordered by the predicted time of low power, soonest first. Devices with a stable balance
+	// come last, ordered by surplus.
+	Devices []Balance `json:"devices"`
+	// Errors holds the devices that couldn't be analyzed, e.g. because no history was recorded yet.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// Analyzer analyzes the energy balance using the samples of a history store.
+type Analyzer struct {
+	History *history.Store
+	// Window is the duration of history considered. Defaults to 28 days.
+	Window time.Duration
+	// Model defaults to DefaultModel.
+	Model *Model
+}
+
+// Analyze estimates the energy balance of d. Days are in the time zone of now. area is the name of the area using the device, or empty.
+func (a Analyzer) Analyze(d miyo.Device, area string, now time.Time) (Balance, error) {
+	window := a.Window
+	if window <= 0 {
+		window = 28 * day
+	}
+	m := DefaultModel
+	if a.Model != nil {
+		m = *a.Model
+	}
+
+	q := history.Query{
+		ID:         d.Reference().String(),
+		From:       now.Add(-window),
+		To:         now,
+		Resolution: time.Hour,
+	}
+	var series [4][]history.Sample
+	for i, metric := range []string{"chargingDurationDay", "charging", "solarVoltage", "lowPower"} {
+		q.Metric = metric
+		samples, err := a.History.Query(q)
+		if err != nil {
+			return Balance{}, err
+		}
+		series[i] = samples
+	}
+
+	return Analyze(d, area, Days(now.Location(), series[0], series[1], series[2], series[3]), now, m)
+}
+
+// Report analyzes all devices of snap over the week before snap.Time. The trend is based on the Analyzer's
+// window.
+func (a Analyzer) Report(snap miyo.Snapshot) Report {
+	r := Report{
+		From: snap.Time.Add(-7 * day),
+		To:   snap.Time,
+	}
+	topo := snap.Topology()
+
+	for _, d := range topo.Devices {
+		var area string
+		if c, ok := topo.CircuitOf(d.Ref); ok {
+			area = c.Name
+		}
+
+		b, err := a.Analyze(d, area, snap.Time)
+		if err != nil {
+			r.Errors = append(r.Errors, err.Error())
+			continue
+		}
+		r.Devices = append(r.Devices, b)
+	}
+
+	sort.SliceStable(r.Devices, func(i, j int) bool {
+		bi, bj := r.Devices[i], r.Devices[j]
+		switch {
+		case bi.LowPowerAt.IsZero() && bj.LowPowerAt.IsZero():
+			return bi.Surplus < bj.Surplus
+		case bi.LowPowerAt.IsZero() || bj.LowPowerAt.IsZero():
+			return !bi.LowPowerAt.IsZero()
+		default:
+			return bi.LowPowerAt.Before(bj.LowPowerAt)
+		}
+	})
+
+	return r
+}
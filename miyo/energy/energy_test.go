@@ -0,0 +1,189 @@
+package energy
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/octo/miyo-go/miyo"
+	"github.com/octo/miyo-go/miyo/history"
+)
+
+func TestDays(t *testing.T) {
+	start := time.Date(2022, 9, 1, 0, 0, 0, 0, time.UTC)
+	var duration, charging, voltage, lowPower []history.Sample
+	// chargingDurationDay is a running total: 5 minutes in every charging hour on the first day, 7 on the second.
+	total := 0.0
+	for h := 0; h < 48; h++ {
+		ts := start.Add(time.Duration(h) * time.Hour)
+		if h%24 == 0 {
+			total = 0
+		}
+		c, v := 0.0, 0.0
+		if h%24 >= 12 && h%24 < 18 {
+			c, v = 1, 5.5
+			total += float64(5 + 2*(h/24))
+		}
+		duration = append(duration, history.Sample{Time: ts, Value: total})
+		charging = append(charging, history.Sample{Time: ts, Value: c})
+		voltage = append(voltage, history.Sample{Time: ts, Value: v})
+		lp := 0.0
+		if h == 30 {
+			lp = 1
+		}
+		lowPower = append(lowPower, history.Sample{Time: ts, Value: lp})
+	}
+
+	got := Days(time.UTC, duration, charging, voltage, lowPower)
+	want := []Day{
+		{Date: start, Charging: 30, ChargingShare: 0.25, SolarVoltage: 1.375},
+		{Date: start.Add(24 * time.Hour), Charging: 42, ChargingShare: 0.25, SolarVoltage: 1.375, LowPower: true},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Days() differs (-want/+got):\n%s", diff)
+	}
+
+	// Days are split at midnight of the given time zone: 23:30 UTC is the next day in Berlin.
+	berlin := time.FixedZone("CEST", 2*60*60)
+	late := []history.Sample{{Time: time.Date(2022, 9, 1, 23, 30, 0, 0, time.UTC), Value: 30}}
+	if got, want := Days(berlin, late, nil, nil, nil)[0].Date, time.Date(2022, 9, 2, 0, 0, 0, 0, berlin); !got.Equal(want) {
+		t.Errorf("Days(berlin) date = %v, want %v", got, want)
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	defer miyo.SetLanguage(miyo.CurrentLanguage())
+
+	now := time.Date(2022, 9, 29, 12, 0, 0, 0, time.UTC)
+	valve := miyo.Device{
+		ID:    "{e00cc4d9-c0fa-41c3-aa8e-7ba088dc0f77}",
+		Type:  "valve",
+		State: miyo.DeviceState{Reachable: true},
+	}
+
+	// The valve's spot gets less sun every day: it charged 70 minutes four weeks ago, 16 minutes yesterday.
+	var shady []Day
+	for i := 0; i < 28; i++ {
+		shady = append(shady, Day{
+			Date:     now.Add(-time.Duration(28-i) * day).Truncate(day),
+			Charging: 70 - 2*float64(i),
+		})
+	}
+
+	got, err := Analyze(valve, "Rosen", shady, now, DefaultModel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(got.Trend+2) > 1e-9 {
+		t.Errorf("Trend = %v, want -2", got.Trend)
+	}
+	if math.Abs(got.Charging-22) > 1e-9 || got.Required != 60 || math.Abs(got.Surplus+38) > 1e-9 {
+		t.Errorf("Charging = %v, Required = %v, Surplus = %v, want 22, 60, -38", got.Charging, got.Required, got.Surplus)
+	}
+	if got.LowPowerAt.IsZero() || got.LowPowerIn <= 0 || got.LowPowerIn > 14*day {
+		t.Errorf("LowPowerIn = %v, want within two weeks", got.LowPowerIn)
+	}
+	if !got.LowPowerAt.Equal(now.Add(got.LowPowerIn)) {
+		t.Errorf("LowPowerAt = %v, want now + %v", got.LowPowerAt, got.LowPowerIn)
+	}
+	if diff := cmp.Diff("valve e00cc4d9 (Rosen): charging 22 of 60 min/day, trend -2.0 min/day per day", got.Summary()); diff != "" {
+		t.Errorf("Summary() differs (-want/+got):\n%s", diff)
+	}
+
+	// A sensor in the sun charges more than it needs.
+	sensor := miyo.Device{ID: "{a6563d0a}", Type: "moistureOutdoor"}
+	var sunny []Day
+	for _, d := range shady {
+		sunny = append(sunny, Day{Date: d.Date, Charging: 90})
+	}
+	got, err = Analyze(sensor, "", sunny, now, DefaultModel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.LowPowerAt.IsZero() || got.Level != 1 || got.Trend != 0 {
+		t.Errorf("Analyze(sunny) = %+v, want stable, full battery", got)
+	}
+	if diff := cmp.Diff("sensor a6563d0a has a stable energy balance", got.String()); diff != "" {
+		t.Errorf("String() differs (-want/+got):\n%s", diff)
+	}
+
+	// A device already reporting low power. Its name is translated, too.
+	miyo.SetLanguage("de")
+	valve.State.LowPower = true
+	got, err = Analyze(valve, "Rosen", shady, now, DefaultModel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.LowPowerAt.Equal(now) || got.Level > DefaultModel.LowPowerLevel {
+		t.Errorf("Analyze(low power) = %v at level %v, want now at or below %v", got.LowPowerAt, got.Level, DefaultModel.LowPowerLevel)
+	}
+	if diff := cmp.Diff("Ventil e00cc4d9 (Rosen) meldet einen schwachen Akku", got.String()); diff != "" {
+		t.Errorf("String() differs (-want/+got):\n%s", diff)
+	}
+
+	if _, err := Analyze(valve, "Rosen", nil, now, DefaultModel); err == nil {
+		t.Error("Analyze(no data) succeeded, want error")
+	}
+}
+
+func TestReport(t *testing.T) {
+	store, err := history.Open(t.TempDir(), history.DefaultOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2022, 9, 29, 12, 0, 0, 0, time.UTC)
+	charging := map[string]float64{
+		"{364795e9}": 90,
+		"{a6563d0a}": 5,
+		"{e00cc4d9}": 40,
+	}
+	var samples []history.Sample
+	for h := 14 * 24; h > 0; h -= 6 {
+		ts := now.Add(-time.Duration(h) * time.Hour)
+		for id, minutes := range charging {
+			samples = append(samples,
+				history.Sample{Time: ts, ID: id, Metric: "chargingDurationDay", Value: minutes},
+				history.Sample{Time: ts, ID: id, Metric: "lowPower", Value: 0},
+			)
+		}
+	}
+	if err := store.Add(samples...); err != nil {
+		t.Fatal(err)
+	}
+
+	snap := miyo.Snapshot{
+		Time: now,
+		Areas: miyo.AreaList{
+			{ID: "{rosen}", Name: "Rosen", Sensor: "{a6563d0a}"},
+		},
+		Devices: miyo.DeviceList{
+			{ID: "{364795e9}", Type: "moistureOutdoor"},
+			{ID: "{a6563d0a}", Type: "moistureOutdoor"},
+			{ID: "{e00cc4d9}", Type: "valve"},
+			{ID: "{f223afe9}", Type: "valve"},
+		},
+	}
+
+	r := Analyzer{History: store}.Report(snap)
+
+	var got []string
+	for _, b := range r.Devices {
+		got = append(got, b.Name)
+	}
+	want := []string{
+		"sensor a6563d0a (Rosen)",
+		"valve e00cc4d9",
+		"sensor 364795e9",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Report() order differs (-want/+got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"valve f223afe9: no charging time recorded"}, r.Errors); diff != "" {
+		t.Errorf("Report() errors differ (-want/+got):\n%s", diff)
+	}
+	if !r.From.Equal(now.Add(-7 * day)) {
+		t.Errorf("From = %v, want %v", r.From, now.Add(-7*day))
+	}
+}
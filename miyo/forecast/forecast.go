@@ -0,0 +1,236 @@
+// Package forecast predicts when the soil moisture of an irrigation area will drop below its lower bound.
+//
+// Soil dries roughly exponentially between irrigations. The predictor fits an exponential drying curve to the
+// moisture recorded since the last irrigation (or rain) and extrapolates it to CircuitParams.BorderBottom. If there
+// is not enough data for a fit, a typical drying rate of the area's soil type is used instead. Both are adjusted
+// for the current temperature: the drying rate doubles with every 10 °C.
+package forecast
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/octo/miyo-go/miyo"
+	"github.com/octo/miyo-go/miyo/history"
+)
+
+// SoilRates holds the typical relative moisture loss per hour at 20 °C, keyed by soil type.
+var SoilRates = map[miyo.SoilType]float64{
+	miyo.SoilType_Loamy:      0.005,
+	miyo.SoilType_Sandy:      0.012,
+	miyo.SoilType_LoamySandy: 0.008,
+	miyo.SoilType_Unknown:    0.008,
+}
+
+const (
+	// referenceTemperature is the temperature SoilRates apply to.
+	referenceTemperature = 20.0
+	// wettingThreshold is the moisture increase that is considered irrigation or rain.
+	wettingThreshold = 2.0
+	// minFitPoints and minFitSpan are required to fit a drying curve.
+	minFitPoints = 3
+	minFitSpan   = 3 * time.Hour
+)
+
+// Prediction is the predicted time an area needs water.
+type Prediction struct {
+	Circuit miyo.Circuit
+	// Moisture is the current moisture and Threshold the circuit's lower bound.
+	Moisture  float64
+	Threshold float64
+	// Rate is the relative moisture loss per hour.
+	Rate float64
+	// Fitted is true if Rate was fitted to recorded data, false if it is the soil type's typical rate.
+	Fitted bool
+	// At is the time moisture is predicted to cross Threshold. It is the current time if moisture already is
+	// at or below the threshold.
+	At time.Time
+	// In is the duration until At.
+	In time.Duration
+}
+
+func init() {
+	miyo.RegisterCatalog(domain, "de", miyo.Catalog{
+		"%s needs water now (moisture %.0f, lower bound %.0f)": "%s braucht jetzt Wasser (Feuchte %.0f, Untergrenze %.0f)",
+		"%s will need water in ~%s":                            "%s braucht in ~%s Wasser",
+	})
+}
+
+// domain is the catalog domain of this package's messages.
+const domain = "forecast"
+
+// tr translates msg of this package.
+func tr(msg string) string {
+	return miyo.Translate(domain, msg)
+}
+
+// String formats the prediction, e.g. "Rosen will need water in ~18h", translated into the current language.
+func (p Prediction) String() string {
+	if p.In <= 0 {
+		return fmt.Sprintf(tr("%s needs water now (moisture %.0f, lower bound %.0f)"), p.Circuit.Name, p.Moisture, p.Threshold)
+	}
+	return fmt.Sprintf(tr("%s will need water in ~%s"), p.Circuit.Name, approx(p.In))
+}
+
+// approx formats d in whole hours, or whole days if longer than two days.
+func approx(d time.Duration) string {
+	hours := int(math.Round(d.Hours()))
+	switch {
+	case hours < 1:
+		return "1h"
+	case hours > 48:
+		return fmt.Sprintf("%dd", int(math.Round(d.Hours()/24)))
+	default:
+		return fmt.Sprintf("%dh", hours)
+	}
+}
+
+// Predict predicts when the moisture of c crosses c.Params.BorderBottom. moisture and temperature are the
+// recorded samples of the circuit's moisture and its sensor's temperature in chronological order, usually
+// of the last one or two days. The current values are taken from c.SensorData if the sensor is reachable.
+func Predict(c miyo.Circuit, moisture, temperature []history.Sample, now time.Time) (Prediction, error) {
+	p := Prediction{
+		Circuit:   c,
+		Threshold: float64(c.Params.BorderBottom),
+	}
+
+	s := c.SensorData.State
+	var temp float64
+	switch {
+	case s.Reachable:
+		p.Moisture = float64(s.Moisture)
+		temp = float64(s.Temperature)
+	case len(moisture) > 0:
+		p.Moisture = moisture[len(moisture)-1].Value
+		temp = mean(temperature, referenceTemperature)
+	default:
+		return Prediction{}, fmt.Errorf("%s: sensor unreachable and no moisture recorded", c.Name)
+	}
+
+	segment := dryingSegment(moisture)
+	if rate, ok := fit(segment); ok {
+		from := segment[0].Time
+		p.Rate = rate * temperatureFactor(temp-mean(between(temperature, from, now), temp))
+		p.Fitted = true
+	} else {
+		rate, ok := SoilRates[c.Params.SoilType]
+		if !ok {
+			rate = SoilRates[miyo.SoilType_Unknown]
+		}
+		p.Rate = rate * temperatureFactor(temp-referenceTemperature)
+	}
+
+	p.At = now
+	if p.Moisture > p.Threshold && p.Threshold > 0 {
+		hours := math.Log(p.Moisture/p.Threshold) / p.Rate
+		p.In = time.Duration(hours * float64(time.Hour))
+		p.At = now.Add(p.In)
+	}
+
+	return p, nil
+}
+
+// temperatureFactor returns the change of the drying rate for a temperature difference of delta °C.
+func temperatureFactor(delta float64) float64 {
+	return math.Pow(2, delta/10)
+}
+
+// dryingSegment returns the samples after the last increase in moisture.
+func dryingSegment(samples []history.Sample) []history.Sample {
+	start := 0
+	for i := 1; i < len(samples); i++ {
+		if samples[i].Value-samples[i-1].Value >= wettingThreshold {
+			start = i
+		}
+	}
+	return samples[start:]
+}
+
+// fit fits m(t) = m₀·e^(−k·t) to samples using linear regression of ln(m) and returns k per hour.
+func fit(samples []history.Sample) (float64, bool) {
+	if len(samples) < minFitPoints || samples[len(samples)-1].Time.Sub(samples[0].Time) < minFitSpan {
+		return 0, false
+	}
+
+	var n, sumX, sumY, sumXX, sumXY float64
+	for _, s := range samples {
+		if s.Value <= 0 {
+			continue
+		}
+		x := s.Time.Sub(samples[0].Time).Hours()
+		y := math.Log(s.Value)
+		n++
+		sumX += x
+		sumY += y
+		sumXX += x * x
+		sumXY += x * y
+	}
+
+	denom := n*sumXX - sumX*sumX
+	if n < minFitPoints || denom == 0 {
+		return 0, false
+	}
+	k := -(n*sumXY - sumX*sumY) / denom
+	if k <= 0 {
+		// Not drying, e.g. because of rain or measurement noise.
+		return 0, false
+	}
+	return k, true
+}
+
+func between(samples []history.Sample, from, to time.Time) []history.Sample {
+	var ret []history.Sample
+	for _, s := range samples {
+		if !s.Time.Before(from) && s.Time.Before(to) {
+			ret = append(ret, s)
+		}
+	}
+	return ret
+}
+
+func mean(samples []history.Sample, def float64) float64 {
+	if len(samples) == 0 {
+		return def
+	}
+	var sum float64
+	for _, s := range samples {
+		sum += s.Value
+	}
+	return sum / float64(len(samples))
+}
+
+// Predictor predicts moisture using the samples of a history store.
+type Predictor struct {
+	History *history.Store
+	// Window is the duration of history considered. Defaults to 48 hours.
+	Window time.Duration
+}
+
+// Predict predicts when the moisture of c crosses its lower bound.
+func (p Predictor) Predict(c miyo.Circuit, now time.Time) (Prediction, error) {
+	window := p.Window
+	if window <= 0 {
+		window = 48 * time.Hour
+	}
+
+	q := history.Query{
+		ID:         c.ID,
+		Metric:     "moisture",
+		From:       now.Add(-window),
+		To:         now,
+		Resolution: time.Hour,
+	}
+	moisture, err := p.History.Query(q)
+	if err != nil {
+		return Prediction{}, err
+	}
+
+	q.Metric = "temperature"
+	temperature, err := p.History.Query(q)
+	if err != nil {
+		return Prediction{}, err
+	}
+
+	return Predict(c, moisture, temperature, now)
+}
@@ -0,0 +1,89 @@
+package forecast
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/octo/miyo-go/miyo"
+	"github.com/octo/miyo-go/miyo/history"
+)
+
+func TestPredict(t *testing.T) {
+	now := time.Date(2022, 6, 21, 12, 0, 0, 0, time.UTC)
+
+	// Irrigation twelve hours ago raised moisture to 70, since then it drops by 1% per hour.
+	var moisture, temperature []history.Sample
+	for h := 24; h > 0; h-- {
+		ts := now.Add(-time.Duration(h) * time.Hour)
+		v := 40.0
+		if h <= 12 {
+			v = 70 * math.Exp(-0.01*float64(12-h))
+		}
+		moisture = append(moisture, history.Sample{Time: ts, Value: v})
+		temperature = append(temperature, history.Sample{Time: ts, Value: 20})
+	}
+
+	c := miyo.Circuit{
+		Name:   "Rosen",
+		Params: miyo.CircuitParams{BorderBottom: 50, SoilType: miyo.SoilType_Sandy},
+	}
+
+	// The sensor is unreachable: the last sample is the current moisture.
+	got, err := Predict(c, moisture, temperature, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Fitted || math.Abs(got.Rate-0.01) > 1e-6 {
+		t.Errorf("Rate = %v (fitted: %v), want fitted rate 0.01", got.Rate, got.Fitted)
+	}
+	wantHours := math.Log(moisture[len(moisture)-1].Value/50) / 0.01
+	if math.Abs(got.In.Hours()-wantHours) > 0.01 {
+		t.Errorf("In = %v, want %.1fh", got.In, wantHours)
+	}
+
+	// A hotter sensor speeds up drying.
+	c.SensorData.State = miyo.DeviceState{Reachable: true, Moisture: 60, Temperature: 30}
+	hot, err := Predict(c, moisture, temperature, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(hot.Rate-0.02) > 1e-6 {
+		t.Errorf("Rate = %v, want 0.02", hot.Rate)
+	}
+	if diff := cmp.Diff("Rosen will need water in ~9h", hot.String()); diff != "" {
+		t.Errorf("String() differs (-want/+got):\n%s", diff)
+	}
+}
+
+func TestPredictFallback(t *testing.T) {
+	now := time.Date(2022, 6, 21, 12, 0, 0, 0, time.UTC)
+	c := miyo.Circuit{
+		Name:       "Rasen",
+		Params:     miyo.CircuitParams{BorderBottom: 40, SoilType: miyo.SoilType_Loamy},
+		SensorData: miyo.Device{State: miyo.DeviceState{Reachable: true, Moisture: 50, Temperature: 20}},
+	}
+
+	got, err := Predict(c, nil, nil, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Fitted || got.Rate != SoilRates[miyo.SoilType_Loamy] {
+		t.Errorf("Rate = %v (fitted: %v), want soil rate %v", got.Rate, got.Fitted, SoilRates[miyo.SoilType_Loamy])
+	}
+
+	c.SensorData.State.Moisture = 35
+	got, err = Predict(c, nil, nil, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.In != 0 || !got.At.Equal(now) {
+		t.Errorf("Predict(dry) = %v, want now", got)
+	}
+
+	c.SensorData.State.Reachable = false
+	if _, err := Predict(c, nil, nil, now); err == nil {
+		t.Error("Predict(no data) succeeded, want error")
+	}
+}
@@ -0,0 +1,85 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// maxAuditBody is the maximum number of bytes of a request body that are written to the audit log.
+const maxAuditBody = 4096
+
+// AuditEntry is one entry of the audit log.
+type AuditEntry struct {
+	Time   time.Time `json:"time"`
+	Token  string    `json:"token"`
+	Role   Role      `json:"role"`
+	Method string    `json:"method"`
+	Path   string    `json:"path"`
+	Body   string    `json:"body,omitempty"`
+	Status int       `json:"status"`
+}
+
+// AuditLog writes one JSON encoded AuditEntry per line.
+type AuditLog struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewAuditLog returns an audit log writing to w.
+func NewAuditLog(w io.Writer) *AuditLog {
+	return &AuditLog{
+		enc: json.NewEncoder(w),
+	}
+}
+
+func (l *AuditLog) write(e AuditEntry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := l.enc.Encode(e); err != nil {
+		log.Printf("writing audit log: %v", err)
+	}
+}
+
+// serve calls next and logs the request along with its response status.
+func (l *AuditLog) serve(next http.Handler, w http.ResponseWriter, r *http.Request, t Token) {
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxAuditBody+1))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	r.Body = ioutil.NopCloser(io.MultiReader(bytes.NewReader(body), r.Body))
+	if len(body) > maxAuditBody {
+		body = append(body[:maxAuditBody], "…"...)
+	}
+
+	sr := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+	next.ServeHTTP(sr, r)
+
+	l.write(AuditEntry{
+		Time:   time.Now(),
+		Token:  t.Name,
+		Role:   t.Role,
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Body:   string(body),
+		Status: sr.status,
+	})
+}
+
+// statusRecorder records the status code written by a handler.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (sr *statusRecorder) WriteHeader(code int) {
+	sr.status = code
+	sr.ResponseWriter.WriteHeader(code)
+}
@@ -0,0 +1,226 @@
+// Package gateway restricts access to the REST API of package server.
+//
+// Clients authenticate with tokens issued by the gateway instead of the MIYO Cube's API key. Each token has a role
+// and may be limited to some circuits. All requests that change the state of the MIYO Cube are written to an
+// audit log.
+package gateway
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/octo/miyo-go/miyo"
+	"github.com/octo/miyo-go/miyo/server"
+)
+
+// Role determines which actions a token allows.
+type Role int
+
+const (
+	// Role_ReadOnly allows reading the state of circuits and devices.
+	Role_ReadOnly Role = iota
+	// Role_Irrigate additionally allows starting and stopping irrigation and opening and closing valves.
+	Role_Irrigate
+	// Role_Admin allows all actions, including changing parameters.
+	Role_Admin
+)
+
+var roleNames = map[Role]string{
+	Role_ReadOnly: "read-only",
+	Role_Irrigate: "irrigate",
+	Role_Admin:    "admin",
+}
+
+func (r Role) String() string {
+	if name, ok := roleNames[r]; ok {
+		return name
+	}
+	return fmt.Sprintf("Role#%d", r)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (r Role) MarshalText() ([]byte, error) {
+	if _, ok := roleNames[r]; !ok {
+		return nil, fmt.Errorf("invalid role %d", r)
+	}
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (r *Role) UnmarshalText(text []byte) error {
+	for role, name := range roleNames {
+		if name == string(text) {
+			*r = role
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid role %q", text)
+}
+
+// allows reports whether the role permits action.
+func (r Role) allows(action server.Action) bool {
+	switch r {
+	case Role_Admin:
+		return true
+	case Role_Irrigate:
+		return action == server.Action_Read || action == server.Action_Irrigate
+	case Role_ReadOnly:
+		return action == server.Action_Read
+	default:
+		return false
+	}
+}
+
+// Token grants access to the API.
+type Token struct {
+	Name string `json:"name"`
+	// Hash is the hex encoded SHA-256 hash of the token's secret. The secret itself is not stored.
+	Hash string `json:"hash"`
+	Role Role   `json:"role"`
+	// Circuits limits the token to the circuits with these IDs or names. If empty, all circuits are allowed.
+	Circuits []string `json:"circuits,omitempty"`
+}
+
+// allowsCircuit reports whether the token is valid for c.
+// Devices not belonging to any circuit, passed as the zero Circuit, are only accessible with unrestricted tokens.
+func (t Token) allowsCircuit(c miyo.Circuit) bool {
+	if len(t.Circuits) == 0 {
+		return true
+	}
+	if c.ID == "" {
+		return false
+	}
+
+	for _, idOrName := range t.Circuits {
+		if idOrName == c.ID || strings.EqualFold(idOrName, c.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewToken creates a new token with a random secret.
+// The secret is returned to be handed out to the client; only its hash is stored in the token.
+func NewToken(name string, role Role, circuits []string) (secret string, t Token, err error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", Token{}, err
+	}
+	secret = hex.EncodeToString(buf)
+
+	return secret, Token{
+		Name:     name,
+		Hash:     HashSecret(secret),
+		Role:     role,
+		Circuits: circuits,
+	}, nil
+}
+
+// HashSecret returns the hex encoded SHA-256 hash of secret.
+func HashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// LoadTokens reads tokens from a JSON file containing a list of tokens.
+func LoadTokens(fileName string) ([]Token, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	var tokens []Token
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return nil, fmt.Errorf("%s: %w", fileName, err)
+	}
+	return tokens, nil
+}
+
+// SaveTokens writes tokens to a JSON file.
+func SaveTokens(fileName string, tokens []Token) error {
+	data, err := json.MarshalIndent(tokens, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(fileName, append(data, '\n'), 0600)
+}
+
+type tokenKey struct{}
+
+// Gateway authenticates requests and decides which actions they may perform.
+// It implements server.Policy.
+type Gateway struct {
+	Tokens []Token
+	// Audit receives an entry for every request that changes the state of the MIYO Cube. It is required.
+	Audit *AuditLog
+}
+
+// Handler returns a handler that authenticates requests to the API before passing them to next.
+// The token is read from the "Authorization: Bearer <secret>" header only, so it doesn't end up in access logs or
+// the browser history. Requests outside of "/api/", i.e. the static files of the dashboard, don't require a token.
+// Handler panics if g.Audit is nil, since every write has to be audited.
+func (g *Gateway) Handler(next http.Handler) http.Handler {
+	if g.Audit == nil {
+		panic("gateway: Audit is nil")
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/api/") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		t, ok := g.authenticate(r)
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="miyo"`)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprintln(w, `{"error":"invalid or missing token"}`)
+			return
+		}
+
+		r = r.WithContext(context.WithValue(r.Context(), tokenKey{}, t))
+		if r.Method == http.MethodGet {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		g.Audit.serve(next, w, r, t)
+	})
+}
+
+func (g *Gateway) authenticate(r *http.Request) (Token, bool) {
+	h := r.Header.Get("Authorization")
+	if !strings.HasPrefix(h, "Bearer ") {
+		return Token{}, false
+	}
+	secret := strings.TrimPrefix(h, "Bearer ")
+	if secret == "" {
+		return Token{}, false
+	}
+
+	hash := []byte(HashSecret(secret))
+	for _, t := range g.Tokens {
+		if subtle.ConstantTimeCompare(hash, []byte(t.Hash)) == 1 {
+			return t, true
+		}
+	}
+	return Token{}, false
+}
+
+// Allow implements server.Policy.
+func (g *Gateway) Allow(r *http.Request, action server.Action, c miyo.Circuit) bool {
+	t, ok := r.Context().Value(tokenKey{}).(Token)
+	if !ok {
+		return false
+	}
+	return t.Role.allows(action) && t.allowsCircuit(c)
+}
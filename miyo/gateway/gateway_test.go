@@ -0,0 +1,165 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/octo/miyo-go/miyo"
+	"github.com/octo/miyo-go/miyo/server"
+)
+
+type fakeCube struct {
+	snap miyo.Snapshot
+}
+
+func (c *fakeCube) Snapshot(_ context.Context) (miyo.Snapshot, error) {
+	return c.snap, nil
+}
+
+func (*fakeCube) SetIrrigation(context.Context, string, bool) error      { return nil }
+func (*fakeCube) SetAutomaticMode(context.Context, string, bool) error   { return nil }
+func (*fakeCube) SetValve(context.Context, miyo.DeviceRef, bool) error   { return nil }
+func (*fakeCube) SetSchedule(context.Context, string, int, string) error { return nil }
+
+func TestGateway(t *testing.T) {
+	cube := &fakeCube{
+		snap: miyo.Snapshot{
+			Areas: []miyo.Circuit{
+				{ID: "{a48b7871}", Name: "Rasen", Valves: map[string]miyo.Valve{"0": {ID: "{f223afe9}"}}},
+				{ID: "{b85746b6}", Name: "Rosen", Valves: map[string]miyo.Valve{"0": {ID: "{e00cc4d9}"}}},
+			},
+			Devices: []miyo.Device{
+				{ID: "{f223afe9}", Type: "valve"},
+				{ID: "{e00cc4d9}", Type: "valve"},
+			},
+		},
+	}
+	srv := &server.Server{Cube: cube}
+	srv.Update(cube.snap)
+
+	var audit bytes.Buffer
+	g := &Gateway{
+		Tokens: []Token{
+			{Name: "kiosk", Hash: HashSecret("kiosk-secret"), Role: Role_ReadOnly, Circuits: []string{"rosen"}},
+			{Name: "gardener", Hash: HashSecret("gardener-secret"), Role: Role_Irrigate, Circuits: []string{"{b85746b6}"}},
+			{Name: "admin", Hash: HashSecret("admin-secret"), Role: Role_Admin},
+		},
+		Audit: NewAuditLog(&audit),
+	}
+	srv.Policy = g
+	h := g.Handler(srv.Handler())
+
+	cases := []struct {
+		secret, method, path, body string
+		wantCode                   int
+	}{
+		{"", "GET", "/api/areas", "", http.StatusUnauthorized},
+		{"wrong", "GET", "/api/areas", "", http.StatusUnauthorized},
+		{"", "GET", "/", "", http.StatusOK},
+		{"kiosk-secret", "GET", "/api/areas/{b85746b6}", "", http.StatusOK},
+		{"kiosk-secret", "GET", "/api/areas/{a48b7871}", "", http.StatusNotFound},
+		{"kiosk-secret", "POST", "/api/areas/{b85746b6}/irrigation", `{"on":true}`, http.StatusForbidden},
+		{"gardener-secret", "POST", "/api/areas/{b85746b6}/irrigation", `{"on":true}`, http.StatusOK},
+		{"gardener-secret", "POST", "/api/devices/{e00cc4d9}/valve", `{"on":true}`, http.StatusOK},
+		{"gardener-secret", "POST", "/api/devices/{f223afe9}/valve", `{"on":true}`, http.StatusNotFound},
+		{"gardener-secret", "POST", "/api/areas/{b85746b6}/automatic-mode", `{"on":false}`, http.StatusForbidden},
+		{"admin-secret", "POST", "/api/areas/{a48b7871}/schedule", `{"day":1,"windows":"06:00-07:00"}`, http.StatusOK},
+	}
+
+	for _, tc := range cases {
+		req := httptest.NewRequest(tc.method, "http://miyo"+(&url.URL{Path: tc.path}).EscapedPath(), strings.NewReader(tc.body))
+		if tc.secret != "" {
+			req.Header.Set("Authorization", "Bearer "+tc.secret)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		if rec.Code != tc.wantCode {
+			t.Errorf("%s %s with %q: got status %d, want %d (%s)", tc.method, tc.path, tc.secret, rec.Code, tc.wantCode, rec.Body)
+		}
+	}
+
+	// Tokens in the URL are not accepted.
+	req := httptest.NewRequest("GET", "http://miyo/api/areas?token=kiosk-secret", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("GET /api/areas?token=…: got status %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+
+	// The list of areas is filtered.
+	req = httptest.NewRequest("GET", "http://miyo/api/areas", nil)
+	req.Header.Set("Authorization", "Bearer kiosk-secret")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	var areas []struct {
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&areas); err != nil {
+		t.Fatal(err)
+	}
+	if len(areas) != 1 || areas[0].Name != "Rosen" {
+		t.Errorf("GET /api/areas = %+v, want only Rosen", areas)
+	}
+
+	type entry struct {
+		Token  string
+		Path   string
+		Status int
+	}
+	var got []entry
+	dec := json.NewDecoder(&audit)
+	for dec.More() {
+		var e AuditEntry
+		if err := dec.Decode(&e); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, entry{e.Token, e.Path, e.Status})
+	}
+
+	want := []entry{
+		{"kiosk", "/api/areas/{b85746b6}/irrigation", http.StatusForbidden},
+		{"gardener", "/api/areas/{b85746b6}/irrigation", http.StatusOK},
+		{"gardener", "/api/devices/{e00cc4d9}/valve", http.StatusOK},
+		{"gardener", "/api/devices/{f223afe9}/valve", http.StatusNotFound},
+		{"gardener", "/api/areas/{b85746b6}/automatic-mode", http.StatusForbidden},
+		{"admin", "/api/areas/{a48b7871}/schedule", http.StatusOK},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("audit log differs (-want/+got):\n%s", diff)
+	}
+}
+
+func TestRoleText(t *testing.T) {
+	for _, r := range []Role{Role_ReadOnly, Role_Irrigate, Role_Admin} {
+		text, err := r.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var got Role
+		if err := got.UnmarshalText(text); err != nil {
+			t.Fatal(err)
+		}
+		if got != r {
+			t.Errorf("UnmarshalText(%q) = %v, want %v", text, got, r)
+		}
+	}
+}
+
+func TestGatewayRequiresAudit(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Handler() with nil Audit did not panic")
+		}
+	}()
+	g := &Gateway{}
+	g.Handler(http.NotFoundHandler())
+}
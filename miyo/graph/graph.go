@@ -0,0 +1,237 @@
+// Package graph renders the topology of a MIYO installation, i.e. the MIYO Cube, its areas and their valves and
+// sensors, as Graphviz DOT or Mermaid flowchart.
+package graph
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/octo/miyo-go/miyo"
+)
+
+// class is the visual class of a node.
+type class string
+
+const (
+	classNone        class = ""
+	classUnreachable class = "unreachable"
+	classLowPower    class = "lowpower"
+	classMissing     class = "missing"
+)
+
+type node struct {
+	id    string
+	lines []string
+	class class
+}
+
+type edge struct {
+	from, to string
+	label    string
+	// unused marks the edges from the cube to devices that aren't used by any area.
+	unused bool
+}
+
+type graph struct {
+	nodes []node
+	edges []edge
+}
+
+// build converts the topology to nodes and edges. Node IDs only consist of letters and digits, so that they are
+// valid identifiers in both formats.
+func build(t *miyo.Topology) graph {
+	g := graph{
+		nodes: []node{{id: "cube", lines: []string{"MIYO Cube"}}},
+	}
+
+	devIDs := map[miyo.DeviceRef]string{}
+	for i, d := range t.Devices {
+		id := fmt.Sprintf("dev%d", i)
+		devIDs[d.Ref] = id
+		g.nodes = append(g.nodes, deviceNode(id, d))
+	}
+
+	for i, ct := range t.Circuits {
+		id := fmt.Sprintf("area%d", i)
+		n := node{id: id, lines: []string{ct.Circuit.Name, ct.Circuit.Status()}}
+		if ct.Sensor == nil || !ct.Sensor.State.Reachable {
+			n.class = classUnreachable
+		}
+		g.nodes = append(g.nodes, n)
+		g.edges = append(g.edges, edge{from: "cube", to: id})
+
+		if ct.Sensor != nil {
+			g.edges = append(g.edges, edge{from: id, to: devIDs[ct.Sensor.Ref], label: "sensor"})
+		}
+		isValve := map[miyo.DeviceRef]bool{}
+		for _, d := range ct.Valves {
+			isValve[d.Ref] = true
+			g.edges = append(g.edges, edge{from: id, to: devIDs[d.Ref], label: "valve"})
+		}
+		if ct.SensorValve != nil && !isValve[ct.SensorValve.Ref] {
+			g.edges = append(g.edges, edge{from: id, to: devIDs[ct.SensorValve.Ref], label: "sensor valve"})
+		}
+
+		for j, ref := range ct.Missing {
+			mid := fmt.Sprintf("%smissing%d", id, j)
+			g.nodes = append(g.nodes, node{id: mid, lines: []string{shortID(ref.ID), "not in device list"}, class: classMissing})
+			g.edges = append(g.edges, edge{from: id, to: mid})
+		}
+	}
+
+	for _, d := range t.Orphans() {
+		g.edges = append(g.edges, edge{from: "cube", to: devIDs[d.Ref], label: "unused", unused: true})
+	}
+
+	return g
+}
+
+func deviceNode(id string, d miyo.Device) node {
+	n := node{
+		id: id,
+		lines: []string{
+			fmt.Sprintf("%s %s", d.DeviceType(), shortID(d.ID)),
+			d.Status(),
+			fmt.Sprintf("RSSI %d, %s", d.State.RSSI, battery(d)),
+		},
+	}
+	switch {
+	case !d.State.Reachable:
+		n.class = classUnreachable
+	case d.State.LowPower:
+		n.class = classLowPower
+	}
+	return n
+}
+
+// battery describes the charge state of a device.
+func battery(d miyo.Device) string {
+	switch {
+	case d.State.LowPower:
+		return "battery low"
+	case d.State.Charging:
+		return "charging"
+	default:
+		return "battery ok"
+	}
+}
+
+// shortID returns the first group of a device's UUID, e.g. "f223afe9" for "{f223afe9-f8b9-…}".
+func shortID(id string) string {
+	id = strings.Trim(id, "{}")
+	if i := strings.Index(id, "-"); i != -1 {
+		return id[:i]
+	}
+	return id
+}
+
+// dotAttrs holds the node attributes of each class.
+var dotAttrs = map[class]string{
+	classUnreachable: `color=red, fontcolor=red, style=dashed`,
+	classLowPower:    `color=orange`,
+	classMissing:     `color=red, fontcolor=red, style=dotted`,
+}
+
+// WriteDOT writes the topology as Graphviz DOT graph to w.
+func WriteDOT(w io.Writer, t *miyo.Topology) error {
+	g := build(t)
+
+	var b strings.Builder
+	b.WriteString("digraph miyo {\n")
+	b.WriteString("\trankdir=LR;\n")
+	b.WriteString("\tnode [shape=box];\n")
+
+	for _, n := range g.nodes {
+		var lines []string
+		for _, l := range n.lines {
+			l = strings.ReplaceAll(l, `\`, `\\`)
+			lines = append(lines, strings.ReplaceAll(l, `"`, `\"`))
+		}
+		attrs := fmt.Sprintf(`label="%s"`, strings.Join(lines, `\n`))
+		if n.id == "cube" {
+			attrs += ", shape=box3d"
+		}
+		if a, ok := dotAttrs[n.class]; ok {
+			attrs += ", " + a
+		}
+		fmt.Fprintf(&b, "\t%s [%s];\n", n.id, attrs)
+	}
+
+	for _, e := range g.edges {
+		var attrs []string
+		if e.label != "" {
+			attrs = append(attrs, fmt.Sprintf(`label="%s"`, e.label))
+		}
+		if e.unused {
+			attrs = append(attrs, "style=dashed")
+		}
+		if len(attrs) == 0 {
+			fmt.Fprintf(&b, "\t%s -> %s;\n", e.from, e.to)
+			continue
+		}
+		fmt.Fprintf(&b, "\t%s -> %s [%s];\n", e.from, e.to, strings.Join(attrs, ", "))
+	}
+
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// mermaidStyles holds the classDef styles of each class.
+var mermaidStyles = []struct {
+	class class
+	style string
+}{
+	{classUnreachable, "stroke:#c00,color:#c00,stroke-dasharray:5 5"},
+	{classLowPower, "stroke:#e80"},
+	{classMissing, "stroke:#c00,color:#c00,stroke-dasharray:2 2"},
+}
+
+// WriteMermaid writes the topology as Mermaid flowchart to w.
+func WriteMermaid(w io.Writer, t *miyo.Topology) error {
+	g := build(t)
+
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+
+	members := map[class][]string{}
+	for _, n := range g.nodes {
+		var lines []string
+		for _, l := range n.lines {
+			lines = append(lines, strings.ReplaceAll(l, `"`, "#quot;"))
+		}
+		label := strings.Join(lines, "<br/>")
+		if n.id == "cube" {
+			fmt.Fprintf(&b, "\t%s[[\"%s\"]]\n", n.id, label)
+		} else {
+			fmt.Fprintf(&b, "\t%s[\"%s\"]\n", n.id, label)
+		}
+		if n.class != classNone {
+			members[n.class] = append(members[n.class], n.id)
+		}
+	}
+
+	for _, e := range g.edges {
+		arrow := "-->"
+		if e.unused {
+			arrow = "-.->"
+		}
+		if e.label != "" {
+			arrow += "|" + e.label + "|"
+		}
+		fmt.Fprintf(&b, "\t%s %s %s\n", e.from, arrow, e.to)
+	}
+
+	for _, s := range mermaidStyles {
+		if len(members[s.class]) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\tclassDef %s %s\n", s.class, s.style)
+		fmt.Fprintf(&b, "\tclass %s %s\n", strings.Join(members[s.class], ","), s.class)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,101 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/octo/miyo-go/miyo"
+)
+
+func testTopology() *miyo.Topology {
+	sensor := miyo.Device{
+		ID:    "{a6563d0a-28d2-432c-800f-838496a807de}",
+		Type:  "moistureOutdoor",
+		Ref:   miyo.DeviceRef{ID: "{a6563d0a-28d2-432c-800f-838496a807de}", Channel: 1},
+		State: miyo.DeviceState{Reachable: true, Moisture: 42, RSSI: -70, Charging: true},
+	}
+	valve := miyo.Device{
+		ID:    "{f223afe9-f8b9-46ae-8dcc-a868e96f2d2b}",
+		Type:  "valve",
+		Ref:   miyo.DeviceRef{ID: "{f223afe9-f8b9-46ae-8dcc-a868e96f2d2b}", Channel: 1},
+		State: miyo.DeviceState{Reachable: true, RSSI: -85, LowPower: true},
+	}
+	unused := miyo.Device{
+		ID:    "{e00cc4d9-c0fa-41c3-aa8e-7ba088dc0f77}",
+		Type:  "valve",
+		Ref:   miyo.DeviceRef{ID: "{e00cc4d9-c0fa-41c3-aa8e-7ba088dc0f77}", Channel: 1},
+		State: miyo.DeviceState{RSSI: -200},
+	}
+
+	lawn := miyo.Circuit{
+		ID:          "{a48b7871-4d8b-45fc-90d6-9225f2535926}",
+		Name:        `Rasen "Nord"`,
+		Sensor:      sensor.ID,
+		SensorData:  sensor,
+		SensorValve: miyo.SensorValve{Valve: valve.ID, Channel: 1},
+		Valves: map[string]miyo.Valve{
+			"0": {ID: valve.ID, Channel: 1},
+			"1": {ID: "{364795e9-df24-4b35-a5ab-53598fe38a13}", Channel: 1},
+		},
+	}
+
+	return miyo.NewTopology([]miyo.Circuit{lawn}, miyo.DeviceList{sensor, unused, valve})
+}
+
+func TestWriteDOT(t *testing.T) {
+	var b strings.Builder
+	if err := WriteDOT(&b, testTopology()); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `digraph miyo {
+	rankdir=LR;
+	node [shape=box];
+	cube [label="MIYO Cube", shape=box3d];
+	dev0 [label="moisture_sensor a6563d0a\nmoisture 42\nRSSI -70, charging"];
+	dev1 [label="valve e00cc4d9\nunreachable\nRSSI -200, battery ok", color=red, fontcolor=red, style=dashed];
+	dev2 [label="valve f223afe9\nclosed\nRSSI -85, battery low", color=orange];
+	area0 [label="Rasen \"Nord\"\nmoisture 42"];
+	area0missing0 [label="364795e9\nnot in device list", color=red, fontcolor=red, style=dotted];
+	cube -> area0;
+	area0 -> dev0 [label="sensor"];
+	area0 -> dev2 [label="valve"];
+	area0 -> area0missing0;
+	cube -> dev1 [label="unused", style=dashed];
+}
+`
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("WriteDOT() differs (-want/+got):\n%s", diff)
+	}
+}
+
+func TestWriteMermaid(t *testing.T) {
+	var b strings.Builder
+	if err := WriteMermaid(&b, testTopology()); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `flowchart LR
+	cube[["MIYO Cube"]]
+	dev0["moisture_sensor a6563d0a<br/>moisture 42<br/>RSSI -70, charging"]
+	dev1["valve e00cc4d9<br/>unreachable<br/>RSSI -200, battery ok"]
+	dev2["valve f223afe9<br/>closed<br/>RSSI -85, battery low"]
+	area0["Rasen #quot;Nord#quot;<br/>moisture 42"]
+	area0missing0["364795e9<br/>not in device list"]
+	cube --> area0
+	area0 -->|sensor| dev0
+	area0 -->|valve| dev2
+	area0 --> area0missing0
+	cube -.->|unused| dev1
+	classDef unreachable stroke:#c00,color:#c00,stroke-dasharray:5 5
+	class dev1 unreachable
+	classDef lowpower stroke:#e80
+	class dev2 lowpower
+	classDef missing stroke:#c00,color:#c00,stroke-dasharray:2 2
+	class area0missing0 missing
+`
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("WriteMermaid() differs (-want/+got):\n%s", diff)
+	}
+}
@@ -0,0 +1,116 @@
+package grpcserver
+
+import (
+	"github.com/octo/miyo-go/miyo"
+	pb "github.com/octo/miyo-go/miyo/miyopb"
+)
+
+// SnapshotProto converts a snapshot into its protobuf representation.
+func SnapshotProto(snap miyo.Snapshot) *pb.Snapshot {
+	ret := &pb.Snapshot{
+		Time: snap.Time.Unix(),
+	}
+	for _, c := range snap.Areas {
+		ret.Circuits = append(ret.Circuits, CircuitProto(c))
+	}
+	for _, d := range snap.Devices {
+		ret.Devices = append(ret.Devices, DeviceProto(d))
+	}
+	return ret
+}
+
+// CircuitProto converts a circuit into its protobuf representation.
+func CircuitProto(c miyo.Circuit) *pb.Circuit {
+	p := c.Params
+	schedule := p.Schedule()
+
+	ret := &pb.Circuit{
+		Id:   c.ID,
+		Name: c.Name,
+		Params: &pb.CircuitParams{
+			AutomaticMode:           p.AutomaticMode,
+			ConsiderCharge:          p.ConsiderCharge,
+			ConsiderMower:           p.ConsiderMower,
+			ConsiderWeather:         p.ConsiderWeather,
+			Schedule:                schedule[:],
+			IrrigationDelayForecast: p.IrrigationDelayForecast,
+			IrrigationType:          int32(p.IrrigationType),
+			LocationType:            int32(p.LocationType),
+			PlantType:               int32(p.PlantType),
+			SoilType:                int32(p.SoilType),
+			TemperatureOffset:       int32(p.TemperatureOffset),
+			ValveStaggering:         p.ValveStaggering,
+			BorderBottomPercent:     int32(p.BorderBottom),
+			BorderTopPercent:        int32(p.BorderTop),
+		},
+		SensorValve: &pb.SensorValve{
+			Valve:   c.SensorValve.Valve,
+			Channel: int32(c.SensorValve.Channel),
+		},
+		Valves: make(map[string]*pb.Valve),
+		State: &pb.CircuitState{
+			AutomaticMode:        c.State.AutomaticMode,
+			ExternBlock:          c.State.ExternBlock,
+			Irrigation:           c.State.Irrigation,
+			IrrigationNextEnd:    int64(c.State.IrrigationNextEnd),
+			IrrigationNextStart:  int64(c.State.IrrigationNextStart),
+			ValveStaggeringIndex: int32(c.State.ValveStaggeringIndex),
+			WinterMode:           c.State.WinterMode,
+		},
+		Sensor:     c.Sensor,
+		SensorData: DeviceProto(c.SensorData),
+	}
+
+	for k, v := range c.Valves {
+		ret.Valves[k] = &pb.Valve{
+			Id:      v.ID,
+			Data:    DeviceProto(v.Data),
+			Channel: int32(v.Channel),
+		}
+	}
+
+	return ret
+}
+
+// DeviceProto converts a device into its protobuf representation.
+func DeviceProto(d miyo.Device) *pb.Device {
+	s := d.State
+	return &pb.Device{
+		Channel:    int32(d.Channel),
+		Id:         d.ID,
+		Type:       d.Type,
+		Firmware:   d.Firmware,
+		Ipv6:       d.IPv6,
+		LastUpdate: int64(d.LastUpdate),
+		Ref:        d.Ref.String(),
+		State: &pb.DeviceState{
+			ValveInitialClose:      s.ValveInitialClose,
+			ValveStatus:            s.ValveStatus,
+			OpenValve:              s.OpenValve,
+			LastIrrigationStart:    int64(s.LastIrrigationStart),
+			LastIrrigationEnd:      int64(s.LastIrrigationEnd),
+			LastIrrigationDuration: int64(s.LastIrrigationDuration),
+			Rssi:                   int32(s.RSSI),
+			Reachable:              s.Reachable,
+			SolarVoltage:           int32(s.SolarVoltage),
+			SunWithinWeek:          s.SunWithinWeek,
+			LowPower:               s.LowPower,
+			OtauPossible:           s.OTAUPossible,
+			OtauProgress:           int32(s.OTAUProgress),
+			OtauStatus:             s.OTAUStatus,
+			WinterMode:             s.WinterMode,
+			ChargingDurationDay:    int32(s.ChargingDurationDay),
+			Charging:               s.Charging,
+			ChargingLess:           s.ChargingLess,
+			LastResetTime:          int64(s.LastResetTime),
+			LastResetType:          int32(s.LastResetType),
+			Moisture:               int32(s.Moisture),
+			Brightness:             int32(s.Brightness),
+			Temperature:            int32(s.Temperature),
+			Frequency:              int32(s.Frequency),
+			IrrigationNecessary:    s.IrrigationNecessary,
+			IrrigationPossible:     s.IrrigationPossible,
+			TemperatureOffset:      int32(s.TemperatureOffset),
+		},
+	}
+}
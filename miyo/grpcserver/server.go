@@ -0,0 +1,218 @@
+// Package grpcserver implements the gRPC service defined in proto/miyo/v1/miyo.proto on top of a MIYO Cube.
+package grpcserver
+
+import (
+	"context"
+	"sync"
+
+	"github.com/octo/miyo-go/miyo"
+	pb "github.com/octo/miyo-go/miyo/miyopb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Cube is the subset of *miyo.Conn used by the server.
+type Cube interface {
+	Snapshot(ctx context.Context) (miyo.Snapshot, error)
+	SetIrrigation(ctx context.Context, circuitID string, irrigate bool) error
+	SetAutomaticMode(ctx context.Context, circuitID string, enabled bool) error
+	SetValve(ctx context.Context, ref miyo.DeviceRef, open bool) error
+	SetSchedule(ctx context.Context, circuitID string, day int, windows string) error
+}
+
+// Server implements miyopb.MiyoServer.
+// Queries are answered from the most recent snapshot passed to Update.
+type Server struct {
+	pb.UnimplementedMiyoServer
+
+	Cube Cube
+
+	mu       sync.Mutex
+	snap     *pb.Snapshot
+	watchers map[chan *pb.Snapshot]struct{}
+}
+
+// Update replaces the cached state of the MIYO Cube and notifies watchers if the state of any device or circuit
+// has changed. Its signature allows it to be used as callback for miyo.Conn.Poll.
+func (s *Server) Update(snap miyo.Snapshot) error {
+	next := SnapshotProto(snap)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prev := s.snap
+	s.snap = next
+	if prev != nil && equalIgnoringTime(prev, next) {
+		return nil
+	}
+
+	for ch := range s.watchers {
+		// Drop a pending snapshot the watcher hasn't received yet; only the latest state matters.
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- next
+	}
+
+	return nil
+}
+
+func equalIgnoringTime(a, b *pb.Snapshot) bool {
+	a = proto.Clone(a).(*pb.Snapshot)
+	b = proto.Clone(b).(*pb.Snapshot)
+	a.Time, b.Time = 0, 0
+	return proto.Equal(a, b)
+}
+
+// current returns the cached snapshot, querying the MIYO Cube if Update has not been called yet.
+func (s *Server) current(ctx context.Context) (*pb.Snapshot, error) {
+	s.mu.Lock()
+	snap := s.snap
+	s.mu.Unlock()
+
+	if snap != nil {
+		return snap, nil
+	}
+
+	if err := s.refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.snap, nil
+}
+
+// refresh queries the MIYO Cube and updates the cached snapshot.
+func (s *Server) refresh(ctx context.Context) error {
+	snap, err := s.Cube.Snapshot(ctx)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "querying MIYO Cube: %v", err)
+	}
+	return s.Update(snap)
+}
+
+// ListDevices implements miyopb.MiyoServer.
+func (s *Server) ListDevices(ctx context.Context, _ *pb.ListDevicesRequest) (*pb.ListDevicesResponse, error) {
+	snap, err := s.current(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ListDevicesResponse{Devices: snap.Devices}, nil
+}
+
+// ListCircuits implements miyopb.MiyoServer.
+func (s *Server) ListCircuits(ctx context.Context, _ *pb.ListCircuitsRequest) (*pb.ListCircuitsResponse, error) {
+	snap, err := s.current(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ListCircuitsResponse{Circuits: snap.Circuits}, nil
+}
+
+// GetSnapshot implements miyopb.MiyoServer.
+func (s *Server) GetSnapshot(ctx context.Context, _ *pb.GetSnapshotRequest) (*pb.Snapshot, error) {
+	return s.current(ctx)
+}
+
+// control calls set and refreshes the cached snapshot, so that watchers are notified of the change.
+func (s *Server) control(ctx context.Context, id string, set func() error) error {
+	if id == "" {
+		return status.Error(codes.InvalidArgument, "missing ID")
+	}
+	if err := set(); err != nil {
+		return status.Errorf(codes.Unavailable, "%v", err)
+	}
+	return s.refresh(ctx)
+}
+
+// SetValve implements miyopb.MiyoServer.
+func (s *Server) SetValve(ctx context.Context, req *pb.SetValveRequest) (*pb.SetValveResponse, error) {
+	ref, err := miyo.ParseDeviceRef(req.DeviceId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	err = s.control(ctx, ref.ID, func() error {
+		return s.Cube.SetValve(ctx, ref, req.Open)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SetValveResponse{}, nil
+}
+
+// SetIrrigation implements miyopb.MiyoServer.
+func (s *Server) SetIrrigation(ctx context.Context, req *pb.SetIrrigationRequest) (*pb.SetIrrigationResponse, error) {
+	err := s.control(ctx, req.CircuitId, func() error {
+		return s.Cube.SetIrrigation(ctx, req.CircuitId, req.Irrigate)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SetIrrigationResponse{}, nil
+}
+
+// SetAutomaticMode implements miyopb.MiyoServer.
+func (s *Server) SetAutomaticMode(ctx context.Context, req *pb.SetAutomaticModeRequest) (*pb.SetAutomaticModeResponse, error) {
+	err := s.control(ctx, req.CircuitId, func() error {
+		return s.Cube.SetAutomaticMode(ctx, req.CircuitId, req.Enabled)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SetAutomaticModeResponse{}, nil
+}
+
+// SetSchedule implements miyopb.MiyoServer.
+func (s *Server) SetSchedule(ctx context.Context, req *pb.SetScheduleRequest) (*pb.SetScheduleResponse, error) {
+	if req.Day < 0 || req.Day > 6 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid day %d", req.Day)
+	}
+
+	err := s.control(ctx, req.CircuitId, func() error {
+		return s.Cube.SetSchedule(ctx, req.CircuitId, int(req.Day), req.Windows)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SetScheduleResponse{}, nil
+}
+
+// Watch implements miyopb.MiyoServer.
+func (s *Server) Watch(_ *pb.WatchRequest, stream pb.Miyo_WatchServer) error {
+	ctx := stream.Context()
+
+	// Register before reading the current snapshot, so that no update is missed.
+	ch := make(chan *pb.Snapshot, 1)
+	s.mu.Lock()
+	if s.watchers == nil {
+		s.watchers = make(map[chan *pb.Snapshot]struct{})
+	}
+	s.watchers[ch] = struct{}{}
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.watchers, ch)
+		s.mu.Unlock()
+	}()
+
+	snap, err := s.current(ctx)
+	if err != nil {
+		return err
+	}
+
+	for {
+		if err := stream.Send(snap); err != nil {
+			return err
+		}
+
+		select {
+		case snap = <-ch:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
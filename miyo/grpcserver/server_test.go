@@ -0,0 +1,111 @@
+package grpcserver
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/octo/miyo-go/miyo"
+	pb "github.com/octo/miyo-go/miyo/miyopb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type fakeCube struct {
+	mu   sync.Mutex
+	snap miyo.Snapshot
+}
+
+func (c *fakeCube) Snapshot(_ context.Context) (miyo.Snapshot, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.snap, nil
+}
+
+func (c *fakeCube) SetIrrigation(_ context.Context, id string, irrigate bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := range c.snap.Areas {
+		if c.snap.Areas[i].ID == id {
+			c.snap.Areas[i].State.Irrigation = irrigate
+		}
+	}
+	return nil
+}
+
+func (*fakeCube) SetAutomaticMode(context.Context, string, bool) error   { return nil }
+func (*fakeCube) SetValve(context.Context, miyo.DeviceRef, bool) error   { return nil }
+func (*fakeCube) SetSchedule(context.Context, string, int, string) error { return nil }
+
+func TestServer(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cube := &fakeCube{
+		snap: miyo.Snapshot{
+			Areas: []miyo.Circuit{
+				{ID: "{b85746b6}", Name: "Rosen", Params: miyo.CircuitParams{Day0: "20:00-22:00"}},
+			},
+			Devices: []miyo.Device{
+				{ID: "{e00cc4d9}", Type: "valve"},
+			},
+		},
+	}
+
+	lis := bufconn.Listen(1 << 20)
+	gs := grpc.NewServer()
+	pb.RegisterMiyoServer(gs, &Server{Cube: cube})
+	go gs.Serve(lis)
+	defer gs.Stop()
+
+	cc, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cc.Close()
+	client := pb.NewMiyoClient(cc)
+
+	circuits, err := client.ListCircuits(ctx, &pb.ListCircuitsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(circuits.Circuits); got != 1 {
+		t.Fatalf("len(ListCircuits()) = %d, want 1", got)
+	}
+	if got, want := circuits.Circuits[0].Params.Schedule[0], "20:00-22:00"; got != want {
+		t.Errorf("Schedule[0] = %q, want %q", got, want)
+	}
+
+	watch, err := client.Watch(ctx, &pb.WatchRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	snap, err := watch.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snap.Circuits[0].State.Irrigation {
+		t.Fatal("initial snapshot: irrigation is active, want inactive")
+	}
+
+	if _, err := client.SetIrrigation(ctx, &pb.SetIrrigationRequest{CircuitId: "{b85746b6}", Irrigate: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	snap, err = watch.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !snap.Circuits[0].State.Irrigation {
+		t.Error("snapshot after SetIrrigation(): irrigation is inactive, want active")
+	}
+
+	if _, err := client.SetIrrigation(ctx, &pb.SetIrrigationRequest{}); err == nil {
+		t.Error("SetIrrigation() with missing ID succeeded, want error")
+	}
+}
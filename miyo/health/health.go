@@ -0,0 +1,248 @@
+// Package health checks the valves and sensors of a MIYO Cube for problems, such as weak radio signals, empty
+// batteries or insufficient sun, and grades them with advice on how to fix them.
+package health
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/octo/miyo-go/miyo"
+)
+
+// Grade is the severity of a finding, or the worst severity of a device or report.
+type Grade int
+
+const (
+	Grade_OK Grade = iota
+	Grade_Warning
+	Grade_Critical
+)
+
+var gradeNames = map[Grade]string{
+	Grade_OK:       "ok",
+	Grade_Warning:  "warning",
+	Grade_Critical: "critical",
+}
+
+func init() {
+	miyo.RegisterCatalog(domain, "de", miyo.Catalog{
+		"ok":       "OK",
+		"warning":  "Warnung",
+		"critical": "kritisch",
+		"1 hour":   "1 Stunde",
+		"%d hours": "%d Stunden",
+		"%d days":  "%d Tagen",
+
+		"%s is unreachable":                      "%s ist nicht erreichbar",
+		"%s has a poor signal (%d dBm)":          "%s hat einen sehr schwachen Empfang (%d dBm)",
+		"%s has a weak signal (%d dBm)":          "%s hat einen schwachen Empfang (%d dBm)",
+		"The battery of %s is low":               "Der Akku von %s ist schwach",
+		"%s doesn't charge enough":               "%s lädt nicht ausreichend",
+		"%s had no sun within the last week":     "%s hatte in der letzten Woche keine Sonne",
+		"%s charges only %d minutes a day":       "%s lädt nur %d Minuten am Tag",
+		"%s charges at a low solar voltage (%d)": "%s lädt bei geringer Solarspannung (%d)",
+		"%s hasn't reported for %s":              "%s hat sich seit %s nicht gemeldet",
+		"%s restarted %s ago (reset type %d)":    "%s wurde vor %s neu gestartet (Reset-Typ %d)",
+
+		adviceConnection: "Prüfe den Akku von %s und den Abstand zum MIYO Cube.",
+		adviceSignal:     "Stelle %s näher an den MIYO Cube oder entferne Hindernisse dazwischen.",
+		adviceSun:        "Stelle %s an einen sonnigeren Platz.",
+		"Move %s to a sunnier spot or clean its solar panel.":       "Stelle %s an einen sonnigeren Platz oder reinige das Solarpanel.",
+		"Clean the solar panel of %s or move it to a sunnier spot.": "Reinige das Solarpanel von %s oder stelle es an einen sonnigeren Platz.",
+		"If %s restarts repeatedly, check its battery.":             "Falls %s wiederholt neu startet, prüfe den Akku.",
+	})
+}
+
+// domain is the catalog domain of this package's messages.
+const domain = "health"
+
+// tr translates msg of this package.
+func tr(msg string) string {
+	return miyo.Translate(domain, msg)
+}
+
+func (g Grade) String() string {
+	if name, ok := gradeNames[g]; ok {
+		return tr(name)
+	}
+	return fmt.Sprintf("Grade#%d", g)
+}
+
+// MarshalText implements encoding.TextMarshaler. The text is not translated.
+func (g Grade) MarshalText() ([]byte, error) {
+	name, ok := gradeNames[g]
+	if !ok {
+		return nil, fmt.Errorf("invalid Grade %d", g)
+	}
+	return []byte(name), nil
+}
+
+// Thresholds configures when a check reports a warning or a critical finding.
+type Thresholds struct {
+	// WeakRSSI and PoorRSSI are the signal strengths in dBm below which the signal is weak or poor.
+	WeakRSSI int `json:"weakRSSI"`
+	PoorRSSI int `json:"poorRSSI"`
+	// MinChargingDuration is the minimum average charging time per day, in minutes, as reported by
+	// DeviceState.ChargingDurationDay.
+	MinChargingDuration int `json:"minChargingDuration"`
+	// MinSolarVoltage is the solar voltage, as reported by DeviceState.SolarVoltage, below which the solar panel
+	// of a charging device is considered dirty or shaded. It is only checked while the device charges, since the
+	// voltage drops at dusk and is zero at night.
+	MinSolarVoltage int `json:"minSolarVoltage"`
+	// StaleAfter and LostAfter are the times without update after which a device is considered stale or lost.
+	StaleAfter time.Duration `json:"staleAfter"`
+	LostAfter  time.Duration `json:"lostAfter"`
+	// RecentReset is the time during which a reset of the device is reported.
+	RecentReset time.Duration `json:"recentReset"`
+}
+
+// DefaultThresholds are the thresholds used by Diagnose.
+var DefaultThresholds = Thresholds{
+	WeakRSSI:            -85,
+	PoorRSSI:            -95,
+	MinChargingDuration: 60,
+	MinSolarVoltage:     4,
+	StaleAfter:          24 * time.Hour,
+	LostAfter:           72 * time.Hour,
+	RecentReset:         7 * 24 * time.Hour,
+}
+
+// Finding is the result of one failed check.
+type Finding struct {
+	// Check identifies the check, e.g. "rssi" or "last_update".
+	Check   string `json:"check"`
+	Grade   Grade  `json:"grade"`
+	Message string `json:"message"`
+	Advice  string `json:"advice,omitempty"`
+}
+
+// DeviceReport holds the findings of one device.
+type DeviceReport struct {
+	Device miyo.Device `json:"device"`
+	// Name describes the device in messages, e.g. "valve e00cc4d9 (Rosen)".
+	Name string `json:"name"`
+	// Area is the name of the area using the device, if any.
+	Area string `json:"area,omitempty"`
+	// Grade is the worst grade of the findings.
+	Grade    Grade     `json:"grade"`
+	Findings []Finding `json:"findings"`
+}
+
+// Report is the result of Diagnose.
+type Report struct {
+	Time time.Time `json:"time"`
+	// Grade is the worst grade of all devices.
+	Grade   Grade          `json:"grade"`
+	Devices []DeviceReport `json:"devices"`
+}
+
+// Diagnose checks all devices of snap. Devices are ordered by grade, worst first.
+func Diagnose(snap miyo.Snapshot, th Thresholds) Report {
+	r := Report{Time: snap.Time}
+	topo := snap.Topology()
+
+	for _, d := range topo.Devices {
+		var area string
+		if c, ok := topo.CircuitOf(d.Ref); ok {
+			area = c.Name
+		}
+
+		dr := DiagnoseDevice(d, area, snap.Time, th)
+		if dr.Grade > r.Grade {
+			r.Grade = dr.Grade
+		}
+		r.Devices = append(r.Devices, dr)
+	}
+
+	sort.SliceStable(r.Devices, func(i, j int) bool {
+		return r.Devices[i].Grade > r.Devices[j].Grade
+	})
+
+	return r
+}
+
+// DiagnoseDevice checks one device. area is the name of the area using the device, or empty.
+func DiagnoseDevice(d miyo.Device, area string, now time.Time, th Thresholds) DeviceReport {
+	dr := DeviceReport{
+		Device: d,
+		Name:   d.Describe(area),
+		Area:   area,
+	}
+	// add adds a finding. msg is formatted with the device's name followed by args; advice with the name only.
+	add := func(check string, g Grade, advice, msg string, args ...interface{}) {
+		dr.Findings = append(dr.Findings, Finding{
+			Check:   check,
+			Grade:   g,
+			Message: fmt.Sprintf(tr(msg), append([]interface{}{dr.Name}, args...)...),
+			Advice:  fmt.Sprintf(tr(advice), dr.Name),
+		})
+		if g > dr.Grade {
+			dr.Grade = g
+		}
+	}
+
+	st := d.State
+	if !st.Reachable {
+		add("reachable", Grade_Critical, adviceConnection, "%s is unreachable")
+	} else {
+		switch {
+		case st.RSSI < th.PoorRSSI:
+			add("rssi", Grade_Critical, adviceSignal, "%s has a poor signal (%d dBm)", st.RSSI)
+		case st.RSSI < th.WeakRSSI:
+			add("rssi", Grade_Warning, adviceSignal, "%s has a weak signal (%d dBm)", st.RSSI)
+		}
+	}
+
+	if st.LowPower {
+		add("battery", Grade_Critical, "Move %s to a sunnier spot or clean its solar panel.", "The battery of %s is low")
+	}
+	if st.ChargingLess && !st.Charging {
+		add("charging", Grade_Warning, adviceSun, "%s doesn't charge enough")
+	}
+	if !st.SunWithinWeek {
+		add("sun", Grade_Warning, adviceSun, "%s had no sun within the last week")
+	} else if st.Reachable && st.ChargingDurationDay < th.MinChargingDuration {
+		add("solar", Grade_Warning, "Clean the solar panel of %s or move it to a sunnier spot.",
+			"%s charges only %d minutes a day", st.ChargingDurationDay)
+	}
+	if st.Reachable && st.Charging && st.SolarVoltage < th.MinSolarVoltage {
+		add("solar_voltage", Grade_Warning, "Clean the solar panel of %s or move it to a sunnier spot.",
+			"%s charges at a low solar voltage (%d)", st.SolarVoltage)
+	}
+
+	if updated := d.Updated(); !updated.IsZero() {
+		age := now.Sub(updated)
+		switch {
+		case age > th.LostAfter:
+			add("last_update", Grade_Critical, adviceConnection, "%s hasn't reported for %s", formatAge(age))
+		case age > th.StaleAfter:
+			add("last_update", Grade_Warning, adviceConnection, "%s hasn't reported for %s", formatAge(age))
+		}
+	}
+
+	// A LastResetType of -1 means the device has never been reset.
+	if reset := st.LastReset(); st.LastResetType != -1 && !reset.IsZero() && now.Sub(reset) < th.RecentReset {
+		add("reset", Grade_Warning, "If %s restarts repeatedly, check its battery.",
+			"%s restarted %s ago (reset type %d)", formatAge(now.Sub(reset)), st.LastResetType)
+	}
+
+	return dr
+}
+
+const (
+	adviceConnection = "Check the battery of %s and its distance to the MIYO Cube."
+	adviceSignal     = "Move %s closer to the MIYO Cube or remove obstacles between them."
+	adviceSun        = "Move %s to a sunnier spot."
+)
+
+// formatAge formats d in whole days, or whole hours if shorter than two days.
+func formatAge(d time.Duration) string {
+	if d < 48*time.Hour {
+		if h := int(d.Hours()); h != 1 {
+			return fmt.Sprintf(tr("%d hours"), h)
+		}
+		return tr("1 hour")
+	}
+	return fmt.Sprintf(tr("%d days"), int(d.Hours()/24))
+}
@@ -0,0 +1,191 @@
+package health
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/octo/miyo-go/miyo"
+)
+
+func TestDiagnoseDevice(t *testing.T) {
+	now := time.Unix(1648642654, 0)
+	healthy := miyo.DeviceState{
+		Reachable:           true,
+		RSSI:                -60,
+		SunWithinWeek:       true,
+		ChargingDurationDay: 120,
+		LastResetType:       -1,
+	}
+
+	cases := []struct {
+		name      string
+		state     func(*miyo.DeviceState)
+		updated   time.Duration
+		wantGrade Grade
+		want      []string
+	}{
+		{
+			name:      "healthy",
+			state:     func(*miyo.DeviceState) {},
+			wantGrade: Grade_OK,
+		},
+		{
+			name:      "unreachable",
+			state:     func(s *miyo.DeviceState) { s.Reachable = false; s.RSSI = -200 },
+			wantGrade: Grade_Critical,
+			want:      []string{"valve e00cc4d9 (Rosen) is unreachable"},
+		},
+		{
+			name:      "weak signal",
+			state:     func(s *miyo.DeviceState) { s.RSSI = -88 },
+			wantGrade: Grade_Warning,
+			want:      []string{"valve e00cc4d9 (Rosen) has a weak signal (-88 dBm)"},
+		},
+		{
+			name: "shady spot",
+			state: func(s *miyo.DeviceState) {
+				s.LowPower = true
+				s.ChargingLess = true
+				s.SunWithinWeek = false
+			},
+			wantGrade: Grade_Critical,
+			want: []string{
+				"The battery of valve e00cc4d9 (Rosen) is low",
+				"valve e00cc4d9 (Rosen) doesn't charge enough",
+				"valve e00cc4d9 (Rosen) had no sun within the last week",
+			},
+		},
+		{
+			name:      "short charging",
+			state:     func(s *miyo.DeviceState) { s.ChargingDurationDay = 15 },
+			wantGrade: Grade_Warning,
+			want:      []string{"valve e00cc4d9 (Rosen) charges only 15 minutes a day"},
+		},
+		{
+			name: "short charging between charges",
+			state: func(s *miyo.DeviceState) {
+				s.ChargingDurationDay = 5
+				s.SolarVoltage = 5
+			},
+			wantGrade: Grade_Warning,
+			want:      []string{"valve e00cc4d9 (Rosen) charges only 5 minutes a day"},
+		},
+		{
+			name:      "dirty panel",
+			state:     func(s *miyo.DeviceState) { s.Charging = true; s.SolarVoltage = 2 },
+			wantGrade: Grade_Warning,
+			want:      []string{"valve e00cc4d9 (Rosen) charges at a low solar voltage (2)"},
+		},
+		{
+			name:      "charging in the sun",
+			state:     func(s *miyo.DeviceState) { s.Charging = true; s.SolarVoltage = 6 },
+			wantGrade: Grade_OK,
+		},
+		{
+			name:      "stale",
+			state:     func(*miyo.DeviceState) {},
+			updated:   30 * time.Hour,
+			wantGrade: Grade_Warning,
+			want:      []string{"valve e00cc4d9 (Rosen) hasn't reported for 30 hours"},
+		},
+		{
+			name:      "lost",
+			state:     func(*miyo.DeviceState) {},
+			updated:   3*24*time.Hour + time.Hour,
+			wantGrade: Grade_Critical,
+			want:      []string{"valve e00cc4d9 (Rosen) hasn't reported for 3 days"},
+		},
+		{
+			name: "reset",
+			state: func(s *miyo.DeviceState) {
+				s.LastResetType = 2
+				s.LastResetTime = int(now.Add(-5 * time.Hour).Unix())
+			},
+			wantGrade: Grade_Warning,
+			want:      []string{"valve e00cc4d9 (Rosen) restarted 5 hours ago (reset type 2)"},
+		},
+		{
+			name: "reset an hour ago",
+			state: func(s *miyo.DeviceState) {
+				s.LastResetType = 2
+				s.LastResetTime = int(now.Add(-90 * time.Minute).Unix())
+			},
+			wantGrade: Grade_Warning,
+			want:      []string{"valve e00cc4d9 (Rosen) restarted 1 hour ago (reset type 2)"},
+		},
+	}
+
+	for _, tc := range cases {
+		d := miyo.Device{
+			ID:         "{e00cc4d9-c0fa-41c3-aa8e-7ba088dc0f77}",
+			Type:       "valve",
+			LastUpdate: int(now.Add(-tc.updated).Unix()),
+			State:      healthy,
+		}
+		tc.state(&d.State)
+
+		got := DiagnoseDevice(d, "Rosen", now, DefaultThresholds)
+		if got.Grade != tc.wantGrade {
+			t.Errorf("%s: Grade = %v, want %v", tc.name, got.Grade, tc.wantGrade)
+		}
+
+		var msgs []string
+		for _, f := range got.Findings {
+			msgs = append(msgs, f.Message)
+			if f.Advice == "" {
+				t.Errorf("%s: finding %q has no advice", tc.name, f.Check)
+			}
+		}
+		if diff := cmp.Diff(tc.want, msgs); diff != "" {
+			t.Errorf("%s: messages differ (-want/+got):\n%s", tc.name, diff)
+		}
+	}
+}
+
+func TestDiagnose(t *testing.T) {
+	now := time.Unix(1648642654, 0)
+	device := func(id, typ string, reachable bool) miyo.Device {
+		return miyo.Device{
+			ID:         id,
+			Type:       typ,
+			LastUpdate: int(now.Unix()),
+			State:      miyo.DeviceState{Reachable: reachable, RSSI: -60, SunWithinWeek: true, ChargingDurationDay: 120},
+		}
+	}
+
+	snap := miyo.Snapshot{
+		Time: now,
+		Areas: miyo.AreaList{
+			{ID: "{rosen}", Name: "Rosen", Sensor: "{a6563d0a}"},
+		},
+		Devices: miyo.DeviceList{
+			device("{364795e9}", "moistureOutdoor", true),
+			device("{a6563d0a}", "moistureOutdoor", false),
+		},
+	}
+
+	r := Diagnose(snap, DefaultThresholds)
+	if r.Grade != Grade_Critical {
+		t.Errorf("Grade = %v, want %v", r.Grade, Grade_Critical)
+	}
+
+	var got []string
+	for _, d := range r.Devices {
+		got = append(got, d.Name+": "+d.Grade.String())
+	}
+	want := []string{
+		"sensor a6563d0a (Rosen): critical",
+		"sensor 364795e9: ok",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Diagnose() differs (-want/+got):\n%s", diff)
+	}
+
+	defer miyo.SetLanguage(miyo.CurrentLanguage())
+	miyo.SetLanguage("de")
+	dr := DiagnoseDevice(snap.Devices[1], "Rosen", now, DefaultThresholds)
+	if got, want := dr.Findings[0].Advice, "Prüfe den Akku von Sensor a6563d0a (Rosen) und den Abstand zum MIYO Cube."; got != want {
+		t.Errorf("Advice = %q, want %q", got, want)
+	}
+}
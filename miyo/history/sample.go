@@ -0,0 +1,77 @@
+// Package history records the state of a MIYO Cube over time and allows querying it later.
+package history
+
+import (
+	"time"
+
+	"github.com/octo/miyo-go/miyo"
+)
+
+// Sample is a single value of one metric of a device or circuit.
+type Sample struct {
+	Time time.Time
+	// ID is the reference of the device, e.g. "{uuid};1", or the ID of the circuit.
+	ID string
+	// Metric is the name of the state type, e.g. "moisture" or "irrigation".
+	Metric string
+	Value  float64
+}
+
+// Samples converts a snapshot into samples. Boolean states are recorded as 0 or 1.
+func Samples(snap miyo.Snapshot) []Sample {
+	var ret []Sample
+	add := func(id, metric string, value float64) {
+		ret = append(ret, Sample{
+			Time:   snap.Time,
+			ID:     id,
+			Metric: metric,
+			Value:  value,
+		})
+	}
+
+	for _, d := range snap.Devices {
+		s := d.State
+		// Channels of one unit share the ID, so devices are keyed by their reference.
+		key := d.Reference().String()
+		add(key, "reachable", boolValue(s.Reachable))
+		add(key, "rssi", float64(s.RSSI))
+		add(key, "lowPower", boolValue(s.LowPower))
+		add(key, "charging", boolValue(s.Charging))
+		add(key, "chargingLess", boolValue(s.ChargingLess))
+		add(key, "chargingDurationDay", float64(s.ChargingDurationDay))
+		add(key, "solarVoltage", float64(s.SolarVoltage))
+
+		switch d.DeviceType() {
+		case miyo.DeviceType_Valve:
+			add(key, "valveStatus", boolValue(s.ValveStatus))
+			add(key, "lastIrrigationStart", float64(s.LastIrrigationStart))
+			add(key, "lastIrrigationEnd", float64(s.LastIrrigationEnd))
+			add(key, "lastIrrigationDuration", float64(s.LastIrrigationDuration))
+		case miyo.DeviceType_MoistureSensor:
+			add(key, "moisture", float64(s.Moisture))
+			add(key, "temperature", float64(s.Temperature))
+			add(key, "brightness", float64(s.Brightness))
+		}
+	}
+
+	// The sensor values of an area are recorded with the area, too, since circuits don't report the channel of
+	// their sensor.
+	for _, c := range snap.Areas {
+		add(c.ID, "irrigation", boolValue(c.State.Irrigation))
+		add(c.ID, "automaticMode", boolValue(c.State.AutomaticMode))
+		if s := c.SensorData.State; s.Reachable {
+			add(c.ID, "moisture", float64(s.Moisture))
+			add(c.ID, "temperature", float64(s.Temperature))
+			add(c.ID, "brightness", float64(s.Brightness))
+		}
+	}
+
+	return ret
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,41 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/octo/miyo-go/miyo"
+)
+
+func TestSamples(t *testing.T) {
+	snap := miyo.Snapshot{
+		Time: time.Date(2022, 4, 1, 12, 0, 0, 0, time.UTC),
+		Areas: miyo.AreaList{
+			{ID: "{rosen}", SensorData: miyo.Device{State: miyo.DeviceState{Reachable: true, Moisture: 40, Temperature: 18}}},
+		},
+		Devices: miyo.DeviceList{
+			{ID: "{f223afe9}", Channel: 1, Type: "valve", Ref: miyo.DeviceRef{ID: "{f223afe9}", Channel: 1}},
+			{ID: "{f223afe9}", Channel: 2, Type: "valve", Ref: miyo.DeviceRef{ID: "{f223afe9}", Channel: 2}, State: miyo.DeviceState{ValveStatus: true}},
+		},
+	}
+
+	got := map[string]float64{}
+	for _, s := range Samples(snap) {
+		got[s.ID+" "+s.Metric] = s.Value
+	}
+
+	want := map[string]float64{
+		"{f223afe9};1 valveStatus": 0,
+		"{f223afe9};2 valveStatus": 1,
+		"{rosen} moisture":         40,
+		"{rosen} temperature":      18,
+	}
+	for key, value := range want {
+		if v, ok := got[key]; !ok || v != value {
+			t.Errorf("sample %q = %v (present: %v), want %v", key, v, ok, value)
+		}
+	}
+	if _, ok := got["{f223afe9} valveStatus"]; ok {
+		t.Error("valve samples are keyed by ID, want reference")
+	}
+}
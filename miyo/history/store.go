@@ -0,0 +1,339 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/octo/miyo-go/miyo"
+)
+
+const (
+	fileSuffix = ".jsonl"
+	dayFormat  = "2006-01-02"
+)
+
+// Options control how long samples are kept.
+type Options struct {
+	// Retention is the duration after which samples are deleted. Zero means samples are kept forever.
+	Retention time.Duration
+	// DownsampleAfter is the age after which samples are aggregated to DownsampleResolution.
+	// Zero disables downsampling.
+	DownsampleAfter      time.Duration
+	DownsampleResolution time.Duration
+}
+
+// DefaultOptions keeps full resolution data for a week, hourly averages for two years.
+var DefaultOptions = Options{
+	Retention:            2 * 365 * 24 * time.Hour,
+	DownsampleAfter:      7 * 24 * time.Hour,
+	DownsampleResolution: time.Hour,
+}
+
+// Store is an on-disk store of samples.
+// Samples are stored in one file per (UTC) day, containing one JSON encoded sample per line.
+type Store struct {
+	dir  string
+	opts Options
+
+	mu sync.Mutex
+}
+
+// Open opens the store in directory dir, creating the directory if necessary.
+func Open(dir string, opts Options) (*Store, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	return &Store{
+		dir:  dir,
+		opts: opts,
+	}, nil
+}
+
+// record is the on-disk representation of a Sample.
+type record struct {
+	Time   int64   `json:"t"`
+	ID     string  `json:"id"`
+	Metric string  `json:"m"`
+	Value  float64 `json:"v"`
+}
+
+// Record stores all samples of snap.
+// Its signature allows it to be used as callback for miyo.Conn.Poll.
+func (s *Store) Record(snap miyo.Snapshot) error {
+	return s.Add(Samples(snap)...)
+}
+
+// Add stores samples.
+func (s *Store) Add(samples ...Sample) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	byDay := make(map[string][]Sample)
+	for _, smpl := range samples {
+		day := smpl.Time.UTC().Format(dayFormat)
+		byDay[day] = append(byDay[day], smpl)
+	}
+
+	for day, samples := range byDay {
+		if err := s.appendFile(day, samples); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) appendFile(day string, samples []Sample) error {
+	f, err := os.OpenFile(s.fileName(day), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for _, smpl := range samples {
+		if err := enc.Encode(record{
+			Time:   smpl.Time.Unix(),
+			ID:     smpl.ID,
+			Metric: smpl.Metric,
+			Value:  smpl.Value,
+		}); err != nil {
+			f.Close()
+			return err
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func (s *Store) fileName(day string) string {
+	return filepath.Join(s.dir, day+fileSuffix)
+}
+
+// days returns the days for which files exist, in chronological order.
+func (s *Store) days() ([]string, error) {
+	infos, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []string
+	for _, fi := range infos {
+		name := fi.Name()
+		if fi.IsDir() || !strings.HasSuffix(name, fileSuffix) {
+			continue
+		}
+		day := strings.TrimSuffix(name, fileSuffix)
+		if _, err := time.Parse(dayFormat, day); err != nil {
+			continue
+		}
+		ret = append(ret, day)
+	}
+
+	sort.Strings(ret)
+	return ret, nil
+}
+
+func (s *Store) readFile(day string, filter func(record) bool) ([]record, error) {
+	f, err := os.Open(s.fileName(day))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var ret []record
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var r record
+		if err := json.Unmarshal(sc.Bytes(), &r); err != nil {
+			return nil, fmt.Errorf("%s: %w", f.Name(), err)
+		}
+		if filter == nil || filter(r) {
+			ret = append(ret, r)
+		}
+	}
+
+	return ret, sc.Err()
+}
+
+// Query selects the samples of one metric of a device or circuit.
+type Query struct {
+	// ID is the reference of the device, e.g. "{uuid};1", or the ID of the circuit, as in Sample.ID.
+	ID     string
+	Metric string
+	// From and To limit the time range. The range includes From and excludes To.
+	From, To time.Time
+	// Resolution, if greater than zero, averages all samples within each interval of the given length.
+	Resolution time.Duration
+}
+
+// Query returns all samples matching q in chronological order.
+func (s *Store) Query(q Query) ([]Sample, error) {
+	if !q.From.Before(q.To) {
+		return nil, fmt.Errorf("invalid time range [%v, %v)", q.From, q.To)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	from, to := q.From.Unix(), q.To.Unix()
+	filter := func(r record) bool {
+		return r.ID == q.ID && r.Metric == q.Metric && r.Time >= from && r.Time < to
+	}
+
+	var records []record
+	for t := q.From.UTC().Truncate(24 * time.Hour); t.Before(q.To); t = t.Add(24 * time.Hour) {
+		r, err := s.readFile(t.Format(dayFormat), filter)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, r...)
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Time < records[j].Time
+	})
+
+	if q.Resolution > 0 {
+		records = downsample(records, q.Resolution)
+	}
+
+	ret := make([]Sample, 0, len(records))
+	for _, r := range records {
+		ret = append(ret, Sample{
+			Time:   time.Unix(r.Time, 0),
+			ID:     r.ID,
+			Metric: r.Metric,
+			Value:  r.Value,
+		})
+	}
+
+	return ret, nil
+}
+
+// downsample averages the records of each series within intervals of length res.
+// The time of an aggregated record is the start of its interval.
+func downsample(records []record, res time.Duration) []record {
+	type key struct {
+		id, metric string
+		bucket     int64
+	}
+	type agg struct {
+		sum float64
+		n   int
+	}
+
+	step := int64(res / time.Second)
+	if step < 1 {
+		step = 1
+	}
+
+	var order []key
+	aggs := make(map[key]*agg)
+	for _, r := range records {
+		k := key{r.ID, r.Metric, r.Time - r.Time%step}
+		a, ok := aggs[k]
+		if !ok {
+			a = &agg{}
+			aggs[k] = a
+			order = append(order, k)
+		}
+		a.sum += r.Value
+		a.n++
+	}
+
+	ret := make([]record, 0, len(order))
+	for _, k := range order {
+		a := aggs[k]
+		ret = append(ret, record{
+			Time:   k.bucket,
+			ID:     k.id,
+			Metric: k.metric,
+			Value:  a.sum / float64(a.n),
+		})
+	}
+
+	return ret
+}
+
+// Compact applies the retention and downsampling options: day files older than the retention period are deleted
+// and day files older than DownsampleAfter are rewritten with aggregated samples.
+// Compact should be called regularly, e.g. once a day.
+func (s *Store) Compact(now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	days, err := s.days()
+	if err != nil {
+		return err
+	}
+
+	for _, day := range days {
+		t, _ := time.Parse(dayFormat, day)
+		// age is the age of the newest sample possibly in the file.
+		age := now.Sub(t.Add(24 * time.Hour))
+
+		switch {
+		case s.opts.Retention > 0 && age > s.opts.Retention:
+			if err := os.Remove(s.fileName(day)); err != nil {
+				return err
+			}
+		case s.opts.DownsampleAfter > 0 && s.opts.DownsampleResolution > 0 && age > s.opts.DownsampleAfter:
+			if err := s.downsampleFile(day); err != nil {
+				return fmt.Errorf("downsampling %s: %w", day, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) downsampleFile(day string) error {
+	records, err := s.readFile(day, nil)
+	if err != nil {
+		return err
+	}
+
+	records = downsample(records, s.opts.DownsampleResolution)
+
+	tmp, err := ioutil.TempFile(s.dir, day+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	enc := json.NewEncoder(w)
+	for _, r := range records {
+		if err := enc.Encode(r); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.fileName(day))
+}
@@ -0,0 +1,107 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStore(t *testing.T) {
+	s, err := Open(t.TempDir(), DefaultOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2022, 4, 1, 23, 0, 0, 0, time.UTC)
+	var samples []Sample
+	for i := 0; i < 12; i++ {
+		samples = append(samples,
+			Sample{Time: start.Add(time.Duration(i) * 10 * time.Minute), ID: "a", Metric: "moisture", Value: float64(i)},
+			Sample{Time: start.Add(time.Duration(i) * 10 * time.Minute), ID: "b", Metric: "moisture", Value: 100},
+		)
+	}
+	if err := s.Add(samples...); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.Query(Query{
+		ID:     "a",
+		Metric: "moisture",
+		From:   start.Add(50 * time.Minute),
+		To:     start.Add(80 * time.Minute),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Sample{
+		{Time: start.Add(50 * time.Minute), ID: "a", Metric: "moisture", Value: 5},
+		{Time: start.Add(60 * time.Minute), ID: "a", Metric: "moisture", Value: 6},
+		{Time: start.Add(70 * time.Minute), ID: "a", Metric: "moisture", Value: 7},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Query() differs (-want/+got):\n%s", diff)
+	}
+
+	got, err = s.Query(Query{
+		ID:         "a",
+		Metric:     "moisture",
+		From:       start,
+		To:         start.Add(2 * time.Hour),
+		Resolution: time.Hour,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want = []Sample{
+		{Time: start, ID: "a", Metric: "moisture", Value: 2.5},
+		{Time: start.Add(time.Hour), ID: "a", Metric: "moisture", Value: 8.5},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Query() with resolution differs (-want/+got):\n%s", diff)
+	}
+}
+
+func TestCompact(t *testing.T) {
+	s, err := Open(t.TempDir(), Options{
+		Retention:            30 * 24 * time.Hour,
+		DownsampleAfter:      24 * time.Hour,
+		DownsampleResolution: time.Hour,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	old := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	recent := time.Date(2022, 4, 1, 12, 0, 0, 0, time.UTC)
+	if err := s.Add(
+		Sample{Time: old, ID: "a", Metric: "moisture", Value: 1},
+		Sample{Time: recent, ID: "a", Metric: "moisture", Value: 10},
+		Sample{Time: recent.Add(30 * time.Minute), ID: "a", Metric: "moisture", Value: 20},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Compact(recent.Add(72 * time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.Query(Query{
+		ID:     "a",
+		Metric: "moisture",
+		From:   old.Add(-time.Hour),
+		To:     recent.Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Sample{
+		{Time: recent, ID: "a", Metric: "moisture", Value: 15},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Query() after Compact() differs (-want/+got):\n%s", diff)
+	}
+}
@@ -0,0 +1,166 @@
+package homeassistant
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/octo/miyo-go/miyo"
+)
+
+// Bridge publishes the state of a MIYO Cube to an MQTT broker and forwards commands from Home Assistant to the cube.
+type Bridge struct {
+	Conn   *miyo.Conn
+	Client mqtt.Client
+	Topics Topics
+
+	mu sync.Mutex
+	// announced holds the config topics of entities that have already been announced.
+	announced map[string]bool
+	// devices and circuits map object IDs used in command topics back to devices and circuit IDs.
+	devices  map[string]miyo.Device
+	circuits map[string]string
+}
+
+// Run publishes discovery and state messages every interval. Publishing errors are logged and retried with the
+// next snapshot. It returns when ctx is cancelled.
+// Commands are only received after Subscribe has been called.
+func (b *Bridge) Run(ctx context.Context, interval time.Duration) error {
+	b.mu.Lock()
+	b.announced = make(map[string]bool)
+	b.mu.Unlock()
+
+	return b.Conn.Poll(ctx, interval, b.publish)
+}
+
+// Subscribe subscribes c to the command topics. Commands are executed using ctx. Brokers drop the subscriptions
+// of clean sessions when the connection is lost, so call Subscribe from the client's OnConnect handler:
+//
+//	opts.SetOnConnectHandler(func(c mqtt.Client) {
+//		if err := b.Subscribe(ctx, c); err != nil {
+//			log.Print(err)
+//		}
+//	})
+func (b *Bridge) Subscribe(ctx context.Context, c mqtt.Client) error {
+	filters := map[string]byte{
+		path.Join(b.Topics.Base, "device", "+", "valve", "set"):       1,
+		path.Join(b.Topics.Base, "circuit", "+", "irrigation", "set"): 1,
+	}
+
+	t := c.SubscribeMultiple(filters, func(_ mqtt.Client, msg mqtt.Message) {
+		if err := b.handleCommand(ctx, msg.Topic(), string(msg.Payload())); err != nil {
+			log.Printf("handling command on %q: %v", msg.Topic(), err)
+		}
+	})
+	if t.Wait(); t.Error() != nil {
+		return fmt.Errorf("subscribing to command topics: %w", t.Error())
+	}
+
+	return nil
+}
+
+func (b *Bridge) handleCommand(ctx context.Context, topic, payload string) error {
+	var on bool
+	switch payload {
+	case payloadOn:
+		on = true
+	case payloadOff:
+		on = false
+	default:
+		return fmt.Errorf("invalid payload %q", payload)
+	}
+
+	// topic has the form "<base>/<kind>/<object ID>/<command>/set".
+	parts := strings.Split(strings.TrimPrefix(topic, b.Topics.Base+"/"), "/")
+	if len(parts) != 4 {
+		return fmt.Errorf("unexpected command topic %q", topic)
+	}
+	kind, oid := parts[0], parts[1]
+
+	b.mu.Lock()
+	d, isDevice := b.devices[oid]
+	id, isCircuit := b.circuits[oid]
+	b.mu.Unlock()
+
+	switch kind {
+	case "device":
+		if !isDevice {
+			return fmt.Errorf("unknown object ID %q", oid)
+		}
+		if d.DeviceType() != miyo.DeviceType_Valve {
+			return fmt.Errorf("device %s is a %s, not a valve", d.ID, d.DeviceType())
+		}
+		return b.Conn.SetValve(ctx, d.Ref, on)
+	case "circuit":
+		if !isCircuit {
+			return fmt.Errorf("unknown object ID %q", oid)
+		}
+		return b.Conn.SetIrrigation(ctx, id, on)
+	default:
+		return fmt.Errorf("unknown command topic %q", topic)
+	}
+}
+
+// publish publishes the entities and states of snap. Errors are logged, so a broker outage doesn't end Run.
+func (b *Bridge) publish(snap miyo.Snapshot) error {
+	b.mu.Lock()
+	b.devices = make(map[string]miyo.Device)
+	for _, d := range snap.Devices {
+		b.devices[DeviceObjectID(d)] = d
+	}
+	b.circuits = make(map[string]string)
+	for _, c := range snap.Areas {
+		b.circuits[ObjectID(c.ID)] = c.ID
+	}
+	b.mu.Unlock()
+
+	for _, e := range b.Topics.Entities(snap) {
+		topic := b.Topics.ConfigTopic(e)
+		if b.announced[topic] {
+			continue
+		}
+		if err := b.publishJSON(topic, e.Config); err != nil {
+			log.Print(err)
+			continue
+		}
+		b.announced[topic] = true
+	}
+
+	for _, d := range snap.Devices {
+		if err := b.publishJSON(b.Topics.DeviceStateTopic(d), DeviceState(d)); err != nil {
+			log.Print(err)
+		}
+	}
+	for _, c := range snap.Areas {
+		if err := b.publishJSON(b.Topics.CircuitStateTopic(c), CircuitState(c)); err != nil {
+			log.Print(err)
+		}
+	}
+
+	return nil
+}
+
+// publishJSON publishes a retained, JSON encoded message.
+func (b *Bridge) publishJSON(topic string, v interface{}) error {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	const (
+		qos      = 1
+		retained = true
+	)
+	t := b.Client.Publish(topic, qos, retained, payload)
+	if t.Wait(); t.Error() != nil {
+		return fmt.Errorf("publishing to %q: %w", topic, t.Error())
+	}
+
+	return nil
+}
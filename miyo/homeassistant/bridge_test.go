@@ -0,0 +1,34 @@
+package homeassistant
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/octo/miyo-go/miyo"
+)
+
+func TestHandleCommand(t *testing.T) {
+	b := &Bridge{Topics: DefaultTopics}
+	if err := b.publish(miyo.Snapshot{}); err != nil {
+		t.Fatal(err)
+	}
+	b.devices["a6563d0a"] = miyo.Device{ID: "{a6563d0a}", Type: "moistureOutdoor"}
+
+	cases := []struct {
+		topic, payload string
+		wantErr        string
+	}{
+		{"miyo/device/a6563d0a/valve/set", "ON", "not a valve"},
+		{"miyo/device/e00cc4d9/valve/set", "ON", "unknown object ID"},
+		{"miyo/circuit/a6563d0a/irrigation/set", "ON", "unknown object ID"},
+		{"miyo/device/a6563d0a/valve/set", "open", "invalid payload"},
+	}
+
+	for _, tc := range cases {
+		err := b.handleCommand(context.Background(), tc.topic, tc.payload)
+		if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+			t.Errorf("handleCommand(%q, %q) = %v, want error containing %q", tc.topic, tc.payload, err, tc.wantErr)
+		}
+	}
+}
@@ -0,0 +1,281 @@
+// Package homeassistant exposes MIYO devices and circuits as Home Assistant entities using MQTT discovery.
+package homeassistant
+
+import (
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/octo/miyo-go/miyo"
+)
+
+const (
+	payloadOn  = "ON"
+	payloadOff = "OFF"
+)
+
+// Entity is a Home Assistant entity announced via MQTT discovery.
+type Entity struct {
+	// Component is the Home Assistant integration, e.g. "sensor" or "switch".
+	Component string
+	// ObjectID identifies the entity within the component.
+	ObjectID string
+	Config   Config
+}
+
+// Config is the discovery payload of an entity.
+type Config struct {
+	Name              string `json:"name"`
+	UniqueID          string `json:"unique_id"`
+	StateTopic        string `json:"state_topic"`
+	CommandTopic      string `json:"command_topic,omitempty"`
+	ValueTemplate     string `json:"value_template,omitempty"`
+	DeviceClass       string `json:"device_class,omitempty"`
+	StateClass        string `json:"state_class,omitempty"`
+	UnitOfMeasurement string `json:"unit_of_measurement,omitempty"`
+	PayloadOn         string `json:"payload_on,omitempty"`
+	PayloadOff        string `json:"payload_off,omitempty"`
+	Device            Device `json:"device"`
+}
+
+// Device groups entities in Home Assistant's device registry.
+type Device struct {
+	Identifiers  []string `json:"identifiers"`
+	Name         string   `json:"name"`
+	Manufacturer string   `json:"manufacturer"`
+	Model        string   `json:"model,omitempty"`
+	SWVersion    string   `json:"sw_version,omitempty"`
+}
+
+// Topics determines the MQTT topics used for discovery, state updates and commands.
+type Topics struct {
+	// Discovery is Home Assistant's discovery prefix, usually "homeassistant".
+	Discovery string
+	// Base is the prefix of all state and command topics, e.g. "miyo".
+	Base string
+}
+
+// DefaultTopics are the topics used if not configured otherwise.
+var DefaultTopics = Topics{
+	Discovery: "homeassistant",
+	Base:      "miyo",
+}
+
+// ConfigTopic returns the topic the discovery payload of e is published to.
+func (t Topics) ConfigTopic(e Entity) string {
+	return path.Join(t.Discovery, e.Component, e.ObjectID, "config")
+}
+
+// DeviceStateTopic returns the topic the state of a device is published to.
+func (t Topics) DeviceStateTopic(d miyo.Device) string {
+	return path.Join(t.Base, "device", DeviceObjectID(d), "state")
+}
+
+// CircuitStateTopic returns the topic the state of a circuit is published to.
+func (t Topics) CircuitStateTopic(c miyo.Circuit) string {
+	return path.Join(t.Base, "circuit", ObjectID(c.ID), "state")
+}
+
+// ValveCommandTopic returns the topic used to open and close a valve.
+func (t Topics) ValveCommandTopic(d miyo.Device) string {
+	return path.Join(t.Base, "device", DeviceObjectID(d), "valve", "set")
+}
+
+// IrrigationCommandTopic returns the topic used to start and stop irrigating a circuit.
+func (t Topics) IrrigationCommandTopic(c miyo.Circuit) string {
+	return path.Join(t.Base, "circuit", ObjectID(c.ID), "irrigation", "set")
+}
+
+// ObjectID converts a MIYO ID, e.g. "{6c6cb2ce-b24b-11ec-a61c-482ae37173b5}", into a string that is safe
+// to use in MQTT topics and Home Assistant object IDs.
+func ObjectID(id string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(id) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == '-' || r == '_':
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
+
+// DeviceObjectID returns the object ID of a device. Devices with several channels share their ID, so the
+// channel of the device's reference is appended, e.g. "f223afe9_f8b9_46ae_8dcc_a868e96f2d2b_2".
+func DeviceObjectID(d miyo.Device) string {
+	ref := d.Ref
+	if ref.ID == "" {
+		ref = miyo.DeviceRef{ID: d.ID, Channel: d.Channel}
+	}
+
+	oid := ObjectID(ref.ID)
+	if ref.Channel != 0 {
+		oid += "_" + strconv.Itoa(ref.Channel)
+	}
+	return oid
+}
+
+// Entities returns all Home Assistant entities for the areas and devices in snap.
+func (t Topics) Entities(snap miyo.Snapshot) []Entity {
+	var ret []Entity
+	for _, d := range snap.Devices {
+		ret = append(ret, t.deviceEntities(d)...)
+	}
+	for _, c := range snap.Areas {
+		ret = append(ret, t.circuitEntities(c)...)
+	}
+	return ret
+}
+
+func (t Topics) deviceEntities(d miyo.Device) []Entity {
+	oid := DeviceObjectID(d)
+	dev := Device{
+		Identifiers:  []string{"miyo_" + oid},
+		Name:         "MIYO " + d.Type + " " + d.ID,
+		Manufacturer: "MIYO",
+		Model:        d.Type,
+		SWVersion:    d.Firmware,
+	}
+	entity := entityFactory{
+		objectID:   oid,
+		stateTopic: t.DeviceStateTopic(d),
+		device:     dev,
+	}.entity
+
+	ret := []Entity{
+		entity("binary_sensor", "reachable", "Reachable", Config{
+			DeviceClass: "connectivity",
+			PayloadOn:   payloadOn,
+			PayloadOff:  payloadOff,
+		}),
+		entity("binary_sensor", "battery", "Battery", Config{
+			DeviceClass: "battery",
+			PayloadOn:   payloadOn,
+			PayloadOff:  payloadOff,
+		}),
+		entity("sensor", "rssi", "Signal strength", Config{
+			DeviceClass:       "signal_strength",
+			StateClass:        "measurement",
+			UnitOfMeasurement: "dBm",
+		}),
+	}
+
+	switch d.DeviceType() {
+	case miyo.DeviceType_Valve:
+		ret = append(ret, entity("switch", "valve", "Valve", Config{
+			CommandTopic: t.ValveCommandTopic(d),
+			PayloadOn:    payloadOn,
+			PayloadOff:   payloadOff,
+		}))
+	case miyo.DeviceType_MoistureSensor:
+		ret = append(ret,
+			entity("sensor", "moisture", "Moisture", Config{
+				DeviceClass:       "moisture",
+				StateClass:        "measurement",
+				UnitOfMeasurement: "%",
+			}),
+			entity("sensor", "temperature", "Temperature", Config{
+				DeviceClass:       "temperature",
+				StateClass:        "measurement",
+				UnitOfMeasurement: "°C",
+			}),
+			entity("sensor", "brightness", "Brightness", Config{
+				DeviceClass:       "illuminance",
+				StateClass:        "measurement",
+				UnitOfMeasurement: "lx",
+			}),
+		)
+	}
+
+	return ret
+}
+
+func (t Topics) circuitEntities(c miyo.Circuit) []Entity {
+	oid := ObjectID(c.ID)
+	dev := Device{
+		Identifiers:  []string{"miyo_" + oid},
+		Name:         "MIYO " + c.Name,
+		Manufacturer: "MIYO",
+		Model:        "circuit",
+	}
+	entity := entityFactory{
+		objectID:   oid,
+		stateTopic: t.CircuitStateTopic(c),
+		device:     dev,
+	}.entity
+
+	return []Entity{
+		entity("binary_sensor", "irrigation", "Irrigation", Config{
+			DeviceClass: "running",
+			PayloadOn:   payloadOn,
+			PayloadOff:  payloadOff,
+		}),
+		entity("switch", "irrigate", "Irrigate", Config{
+			CommandTopic: t.IrrigationCommandTopic(c),
+			PayloadOn:    payloadOn,
+			PayloadOff:   payloadOff,
+		}),
+		entity("sensor", "moisture", "Moisture", Config{
+			DeviceClass:       "moisture",
+			StateClass:        "measurement",
+			UnitOfMeasurement: "%",
+		}),
+	}
+}
+
+type entityFactory struct {
+	objectID   string
+	stateTopic string
+	device     Device
+}
+
+// entity returns an entity whose value is read from the suffix field of the JSON state payload.
+func (f entityFactory) entity(component, suffix, name string, cfg Config) Entity {
+	cfg.Name = name
+	cfg.UniqueID = "miyo_" + f.objectID + "_" + suffix
+	cfg.StateTopic = f.stateTopic
+	cfg.ValueTemplate = "{{ value_json." + suffix + " }}"
+	cfg.Device = f.device
+	return Entity{
+		Component: component,
+		ObjectID:  cfg.UniqueID,
+		Config:    cfg,
+	}
+}
+
+// DeviceState returns the state payload of a device. The keys match the value templates of the device's entities.
+func DeviceState(d miyo.Device) map[string]interface{} {
+	ret := map[string]interface{}{
+		"reachable": onOff(d.State.Reachable),
+		"battery":   onOff(d.State.LowPower),
+		"rssi":      d.State.RSSI,
+	}
+
+	switch d.DeviceType() {
+	case miyo.DeviceType_Valve:
+		ret["valve"] = onOff(d.State.ValveStatus)
+	case miyo.DeviceType_MoistureSensor:
+		ret["moisture"] = d.State.Moisture
+		ret["temperature"] = d.State.Temperature
+		ret["brightness"] = d.State.Brightness
+	}
+
+	return ret
+}
+
+// CircuitState returns the state payload of a circuit. The keys match the value templates of the circuit's entities.
+func CircuitState(c miyo.Circuit) map[string]interface{} {
+	return map[string]interface{}{
+		"irrigation": onOff(c.State.Irrigation),
+		"irrigate":   onOff(c.State.Irrigation),
+		"moisture":   c.SensorData.State.Moisture,
+	}
+}
+
+func onOff(b bool) string {
+	if b {
+		return payloadOn
+	}
+	return payloadOff
+}
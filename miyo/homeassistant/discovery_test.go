@@ -0,0 +1,105 @@
+package homeassistant
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/octo/miyo-go/miyo"
+)
+
+func TestObjectID(t *testing.T) {
+	cases := []struct {
+		id   string
+		want string
+	}{
+		{"{f223afe9-f8b9-46ae-8dcc-a868e96f2d2b}", "f223afe9_f8b9_46ae_8dcc_a868e96f2d2b"},
+		{"{F223AFE9-F8B9};1", "f223afe9_f8b91"},
+		{"", ""},
+	}
+
+	for _, tc := range cases {
+		if got := ObjectID(tc.id); got != tc.want {
+			t.Errorf("ObjectID(%q) = %q, want %q", tc.id, got, tc.want)
+		}
+	}
+}
+
+func TestDeviceObjectID(t *testing.T) {
+	cases := []struct {
+		dev  miyo.Device
+		want string
+	}{
+		{miyo.Device{ID: "{e00cc4d9}"}, "e00cc4d9"},
+		{miyo.Device{ID: "{e00cc4d9}", Channel: 2}, "e00cc4d9_2"},
+		{miyo.Device{ID: "{e00cc4d9}", Ref: miyo.DeviceRef{ID: "{e00cc4d9}", Channel: 12}}, "e00cc4d9_12"},
+	}
+
+	for _, tc := range cases {
+		if got := DeviceObjectID(tc.dev); got != tc.want {
+			t.Errorf("DeviceObjectID(%+v) = %q, want %q", tc.dev, got, tc.want)
+		}
+	}
+}
+
+func TestEntities(t *testing.T) {
+	snap := miyo.Snapshot{
+		Devices: []miyo.Device{
+			{ID: "{e00cc4d9}", Type: "valve"},
+			{ID: "{a6563d0a}", Type: "moistureOutdoor"},
+		},
+		Areas: []miyo.Circuit{
+			{ID: "{b85746b6}", Name: "Rosen"},
+		},
+	}
+
+	got := map[string]string{}
+	for _, e := range DefaultTopics.Entities(snap) {
+		got[DefaultTopics.ConfigTopic(e)] = e.Config.CommandTopic
+	}
+
+	want := map[string]string{
+		"homeassistant/binary_sensor/miyo_e00cc4d9_reachable/config":  "",
+		"homeassistant/binary_sensor/miyo_e00cc4d9_battery/config":    "",
+		"homeassistant/sensor/miyo_e00cc4d9_rssi/config":              "",
+		"homeassistant/switch/miyo_e00cc4d9_valve/config":             "miyo/device/e00cc4d9/valve/set",
+		"homeassistant/binary_sensor/miyo_a6563d0a_reachable/config":  "",
+		"homeassistant/binary_sensor/miyo_a6563d0a_battery/config":    "",
+		"homeassistant/sensor/miyo_a6563d0a_rssi/config":              "",
+		"homeassistant/sensor/miyo_a6563d0a_moisture/config":          "",
+		"homeassistant/sensor/miyo_a6563d0a_temperature/config":       "",
+		"homeassistant/sensor/miyo_a6563d0a_brightness/config":        "",
+		"homeassistant/binary_sensor/miyo_b85746b6_irrigation/config": "",
+		"homeassistant/switch/miyo_b85746b6_irrigate/config":          "miyo/circuit/b85746b6/irrigation/set",
+		"homeassistant/sensor/miyo_b85746b6_moisture/config":          "",
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Entities() differs (-want/+got):\n%s", diff)
+	}
+}
+
+func TestDeviceState(t *testing.T) {
+	d := miyo.Device{
+		Type: "moistureOutdoor",
+		State: miyo.DeviceState{
+			Reachable:   true,
+			RSSI:        -70,
+			Moisture:    42,
+			Temperature: 18,
+			Brightness:  1200,
+		},
+	}
+
+	want := map[string]interface{}{
+		"reachable":   "ON",
+		"battery":     "OFF",
+		"rssi":        -70,
+		"moisture":    42,
+		"temperature": 18,
+		"brightness":  1200,
+	}
+
+	if diff := cmp.Diff(want, DeviceState(d)); diff != "" {
+		t.Errorf("DeviceState() differs (-want/+got):\n%s", diff)
+	}
+}
@@ -0,0 +1,147 @@
+package miyo
+
+import (
+	"os"
+	"strings"
+	"sync"
+)
+
+// domain is the catalog domain of this package's messages.
+const domain = "miyo"
+
+// language is the language of human-readable output, see SetLanguage.
+var language = struct {
+	sync.RWMutex
+	name string
+}{name: "en"}
+
+// SetLanguage selects the language of human-readable output, e.g. "de" or "en". Locale names such as
+// "de_DE.UTF-8" are accepted, too. Messages are written in English and translated using the catalogs of the
+// selected language. It is safe to call SetLanguage while other goroutines translate messages.
+func SetLanguage(lang string) {
+	language.Lock()
+	defer language.Unlock()
+	language.name = baseLanguage(lang)
+}
+
+// CurrentLanguage returns the language selected with SetLanguage, "en" by default.
+func CurrentLanguage() string {
+	language.RLock()
+	defer language.RUnlock()
+	return language.name
+}
+
+// Catalog maps English messages, e.g. "moisture %d", to their translation.
+type Catalog map[string]string
+
+// catalogKey identifies the catalog of one domain in one language.
+type catalogKey struct {
+	domain, lang string
+}
+
+// catalogs holds the registered catalogs. English needs no catalog, since all messages are English.
+var catalogs = struct {
+	sync.RWMutex
+	byKey map[catalogKey]Catalog
+}{
+	byKey: map[catalogKey]Catalog{
+		{domain, "de"}: german,
+	},
+}
+
+// german is the German catalog of this package.
+var german = Catalog{
+	// Device.Status and Circuit.Status
+	"unreachable":       "nicht erreichbar",
+	"open":              "offen",
+	"closed":            "geschlossen",
+	"moisture %d":       "Feuchte %d",
+	"unknown type":      "unbekannter Typ",
+	"irrigation active": "Bewässerung aktiv",
+	"very dry":          "sehr trocken",
+	"dry":               "trocken",
+
+	// Device.Describe
+	"device": "Gerät",
+	"valve":  "Ventil",
+	"sensor": "Sensor",
+
+	// Status and its enums
+	"reachable":     "erreichbar",
+	"unknown":       "unbekannt",
+	"moist":         "feucht",
+	"idle":          "inaktiv",
+	"starting":      "startet",
+	"active":        "aktiv",
+	"stopping":      "stoppt",
+	"low power":     "Akku schwach",
+	"charging less": "lädt zu wenig",
+	"no sun":        "keine Sonne",
+	"ota pending":   "Update verfügbar",
+	"moisture %d%%": "Feuchte %d%%",
+	"irrigation %s": "Bewässerung %s",
+
+	// SoilType
+	"loamy":       "lehmig",
+	"sandy":       "sandig",
+	"loamy_sandy": "lehmig-sandig",
+}
+
+// RegisterCatalog adds the messages of c to the catalog of domain in lang. Other packages and commands use it to
+// make their messages translatable with Translate. The domain, usually the name of the package or command, keeps
+// their messages apart, so the same English message may be translated differently by each of them.
+func RegisterCatalog(domain, lang string, c Catalog) {
+	catalogs.Lock()
+	defer catalogs.Unlock()
+
+	key := catalogKey{domain, baseLanguage(lang)}
+	if catalogs.byKey[key] == nil {
+		catalogs.byKey[key] = Catalog{}
+	}
+	for msg, translation := range c {
+		catalogs.byKey[key][msg] = translation
+	}
+}
+
+// Translate translates msg into the language selected with SetLanguage, using the catalog of domain. Messages
+// without translation are returned unchanged. It may be replaced to use a different translation mechanism.
+var Translate = func(domain, msg string) string {
+	key := catalogKey{domain, CurrentLanguage()}
+
+	catalogs.RLock()
+	defer catalogs.RUnlock()
+
+	if translation, ok := catalogs.byKey[key][msg]; ok {
+		return translation
+	}
+	return msg
+}
+
+// tr translates msg of this package.
+func tr(msg string) string {
+	return Translate(domain, msg)
+}
+
+// LanguageFromEnv returns the language selected by the LC_ALL, LC_MESSAGES or LANG environment variables,
+// e.g. "de" for "de_DE.UTF-8". It returns "en" if none is set.
+func LanguageFromEnv() string {
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if v := os.Getenv(env); v != "" {
+			return baseLanguage(v)
+		}
+	}
+	return "en"
+}
+
+// baseLanguage returns the language part of a locale name, e.g. "de" for "de_DE.UTF-8".
+// The "C" and "POSIX" locales are English.
+func baseLanguage(locale string) string {
+	if i := strings.IndexAny(locale, "_-.@"); i != -1 {
+		locale = locale[:i]
+	}
+	locale = strings.ToLower(locale)
+	if locale == "" || locale == "c" || locale == "posix" {
+		return "en"
+	}
+	return locale
+}
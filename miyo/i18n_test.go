@@ -0,0 +1,86 @@
+package miyo
+
+import (
+	"os"
+	"testing"
+)
+
+func TestTranslate(t *testing.T) {
+	defer SetLanguage(CurrentLanguage())
+
+	c := Circuit{
+		SensorData: Device{State: DeviceState{Reachable: true, Moisture: 40, IrrigationNecessary: true}},
+		Params:     CircuitParams{SoilType: SoilType_LoamySandy},
+	}
+
+	cases := []struct {
+		lang       string
+		wantStatus string
+		wantSoil   string
+	}{
+		{"en", "moisture 40; very dry", "loamy_sandy"},
+		{"de", "Feuchte 40; sehr trocken", "lehmig-sandig"},
+		{"de_AT.UTF-8", "Feuchte 40; sehr trocken", "lehmig-sandig"},
+		{"fr", "moisture 40; very dry", "loamy_sandy"},
+	}
+
+	for _, tc := range cases {
+		SetLanguage(tc.lang)
+		if got := c.Status(); got != tc.wantStatus {
+			t.Errorf("Language %q: Status() = %q, want %q", tc.lang, got, tc.wantStatus)
+		}
+		if got := c.Params.SoilType.String(); got != tc.wantSoil {
+			t.Errorf("Language %q: SoilType.String() = %q, want %q", tc.lang, got, tc.wantSoil)
+		}
+	}
+
+	RegisterCatalog(domain, "fr", Catalog{"very dry": "très sec"})
+	SetLanguage("fr")
+	if got, want := c.StructuredStatus().Dryness.String(), "très sec"; got != want {
+		t.Errorf("after RegisterCatalog: Dryness.String() = %q, want %q", got, want)
+	}
+
+	// Catalogs of other domains don't affect this package.
+	RegisterCatalog("other", "fr", Catalog{"very dry": "extrêmement sec"})
+	if got, want := Translate("other", "very dry"), "extrêmement sec"; got != want {
+		t.Errorf(`Translate("other") = %q, want %q`, got, want)
+	}
+	if got, want := c.StructuredStatus().Dryness.String(), "très sec"; got != want {
+		t.Errorf("after RegisterCatalog(other): Dryness.String() = %q, want %q", got, want)
+	}
+}
+
+func TestLanguageFromEnv(t *testing.T) {
+	vars := []string{"LC_ALL", "LC_MESSAGES", "LANG"}
+	for _, v := range vars {
+		if old, ok := os.LookupEnv(v); ok {
+			defer os.Setenv(v, old)
+		} else {
+			defer os.Unsetenv(v)
+		}
+		os.Unsetenv(v)
+	}
+
+	cases := []struct {
+		env  map[string]string
+		want string
+	}{
+		{map[string]string{}, "en"},
+		{map[string]string{"LANG": "de_DE.UTF-8"}, "de"},
+		{map[string]string{"LANG": "C"}, "en"},
+		{map[string]string{"LANG": "en_US.UTF-8", "LC_MESSAGES": "de_CH"}, "de"},
+		{map[string]string{"LANG": "de_DE.UTF-8", "LC_ALL": "POSIX"}, "en"},
+	}
+
+	for _, tc := range cases {
+		for _, v := range vars {
+			os.Unsetenv(v)
+		}
+		for k, v := range tc.env {
+			os.Setenv(k, v)
+		}
+		if got := LanguageFromEnv(); got != tc.want {
+			t.Errorf("LanguageFromEnv() with %v = %q, want %q", tc.env, got, tc.want)
+		}
+	}
+}
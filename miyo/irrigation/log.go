@@ -0,0 +1,188 @@
+// Package irrigation keeps a log of irrigation events and computes the water used by them.
+package irrigation
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/octo/miyo-go/miyo"
+)
+
+// Event is a single, completed irrigation of one valve.
+type Event struct {
+	// Valve refers to the valve's device. Channels of one unit are separate valves.
+	Valve miyo.DeviceRef `json:"valve"`
+	// Circuit is the ID of the circuit the valve belongs to, if any.
+	Circuit string `json:"circuit,omitempty"`
+	// IrrigationType is the irrigation type of the circuit at the time of the event.
+	IrrigationType int       `json:"irrigationType"`
+	Start          time.Time `json:"start"`
+	End            time.Time `json:"end"`
+}
+
+// Duration returns the duration of the irrigation.
+func (e Event) Duration() time.Duration {
+	return e.End.Sub(e.Start)
+}
+
+// Detect returns all completed irrigation events reported in snap. Times are in the time zone of snap.
+// The MIYO Cube only reports the latest irrigation of each valve, so callers have to remove duplicates.
+func Detect(snap miyo.Snapshot) []Event {
+	circuitOf := func(ref miyo.DeviceRef) (miyo.Circuit, bool) {
+		for _, c := range snap.Areas {
+			for _, v := range c.Valves {
+				if v.Ref().Matches(ref) {
+					return c, true
+				}
+			}
+		}
+		return miyo.Circuit{}, false
+	}
+
+	var ret []Event
+	for _, d := range snap.Devices {
+		if d.DeviceType() != miyo.DeviceType_Valve {
+			continue
+		}
+
+		s := d.State
+		start, end := s.IrrigationStarted(), s.IrrigationEnded()
+		if start.IsZero() || end.Before(start) {
+			// never irrigated or irrigation still in progress
+			continue
+		}
+
+		e := Event{
+			Valve: d.Reference(),
+			Start: start.In(snap.Location()),
+			End:   end.In(snap.Location()),
+		}
+		if c, ok := circuitOf(e.Valve); ok {
+			e.Circuit = c.ID
+			e.IrrigationType = c.Params.IrrigationType
+		}
+		ret = append(ret, e)
+	}
+
+	return ret
+}
+
+// Log is an on-disk log of irrigation events, stored as one JSON encoded event per line.
+type Log struct {
+	fileName string
+
+	mu     sync.Mutex
+	events []Event
+	// latest holds the start time of the most recent event of each valve.
+	latest map[miyo.DeviceRef]time.Time
+}
+
+// OpenLog opens the log stored in fileName. The file is created when the first event is recorded.
+func OpenLog(fileName string) (*Log, error) {
+	l := &Log{
+		fileName: fileName,
+		latest:   make(map[miyo.DeviceRef]time.Time),
+	}
+
+	f, err := os.Open(fileName)
+	if errors.Is(err, os.ErrNotExist) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var e Event
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("%s: %w", fileName, err)
+		}
+		l.add(e)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+func (l *Log) add(e Event) {
+	l.events = append(l.events, e)
+	if e.Start.After(l.latest[e.Valve]) {
+		l.latest[e.Valve] = e.Start
+	}
+}
+
+// latestOf returns the start time of the most recent event of the valve referred to by ref. Events logged
+// without channel count for every channel of the unit.
+func (l *Log) latestOf(ref miyo.DeviceRef) time.Time {
+	var latest time.Time
+	for r, t := range l.latest {
+		if r.Matches(ref) && t.After(latest) {
+			latest = t
+		}
+	}
+	return latest
+}
+
+// Record detects new irrigation events in snap and appends them to the log.
+// Its signature allows it to be used as callback for miyo.Conn.Poll.
+func (l *Log) Record(snap miyo.Snapshot) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var events []Event
+	for _, e := range Detect(snap) {
+		if !e.Start.After(l.latestOf(e.Valve)) {
+			continue
+		}
+		events = append(events, e)
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	f, err := os.OpenFile(l.fileName, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(f)
+	for _, e := range events {
+		if err := enc.Encode(e); err != nil {
+			f.Close()
+			return err
+		}
+		l.add(e)
+	}
+
+	return f.Close()
+}
+
+// Events returns all events that started within [from, to), in chronological order.
+func (l *Log) Events(from, to time.Time) []Event {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var ret []Event
+	for _, e := range l.events {
+		if e.Start.Before(from) || !e.Start.Before(to) {
+			continue
+		}
+		ret = append(ret, e)
+	}
+
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].Start.Before(ret[j].Start)
+	})
+
+	return ret
+}
@@ -0,0 +1,116 @@
+package irrigation
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/octo/miyo-go/miyo"
+)
+
+func TestLog(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "irrigation.jsonl")
+
+	snap := func(start, end int) miyo.Snapshot {
+		return miyo.Snapshot{
+			Devices: []miyo.Device{
+				{
+					ID:   "{valve-a}",
+					Type: "valve",
+					State: miyo.DeviceState{
+						LastIrrigationStart: start,
+						LastIrrigationEnd:   end,
+					},
+				},
+			},
+			Areas: []miyo.Circuit{
+				{
+					ID:     "circuit-a",
+					Params: miyo.CircuitParams{IrrigationType: 2},
+					Valves: map[string]miyo.Valve{
+						"0": {ID: "{valve-a}"},
+					},
+				},
+			},
+		}
+	}
+
+	l, err := OpenLog(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, s := range []miyo.Snapshot{
+		snap(0, 0),
+		snap(1648701000, 1648702800),
+		snap(1648701000, 1648702800),
+		snap(1648787400, 0), // in progress
+		snap(1648787400, 1648788000),
+	} {
+		if err := l.Record(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Re-open the log to make sure events are persisted.
+	l, err = OpenLog(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := l.Events(time.Unix(0, 0), time.Unix(1<<40, 0))
+	want := []Event{
+		{Valve: miyo.DeviceRef{ID: "{valve-a}"}, Circuit: "circuit-a", IrrigationType: 2, Start: time.Unix(1648701000, 0), End: time.Unix(1648702800, 0)},
+		{Valve: miyo.DeviceRef{ID: "{valve-a}"}, Circuit: "circuit-a", IrrigationType: 2, Start: time.Unix(1648787400, 0), End: time.Unix(1648788000, 0)},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Events() differs (-want/+got):\n%s", diff)
+	}
+}
+
+func TestLogChannels(t *testing.T) {
+	l, err := OpenLog(filepath.Join(t.TempDir(), "irrigation.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Both channels of one unit irrigate different areas. Channel 2 runs after channel 1.
+	valve := func(channel, start, end int) miyo.Device {
+		return miyo.Device{
+			ID:      "{f223afe9}",
+			Channel: channel,
+			Ref:     miyo.DeviceRef{ID: "{f223afe9}", Channel: channel},
+			Type:    "valve",
+			State:   miyo.DeviceState{LastIrrigationStart: start, LastIrrigationEnd: end},
+		}
+	}
+	snap := func(devs ...miyo.Device) miyo.Snapshot {
+		return miyo.Snapshot{
+			Devices: devs,
+			Areas: []miyo.Circuit{
+				{ID: "{rasen}", Valves: map[string]miyo.Valve{"0": {ID: "{f223afe9}", Channel: 1}}},
+				{ID: "{rosen}", Valves: map[string]miyo.Valve{"0": {ID: "{f223afe9}", Channel: 2}}},
+			},
+		}
+	}
+
+	for _, s := range []miyo.Snapshot{
+		snap(valve(1, 0, 0), valve(2, 1648701000, 1648702800)),
+		// Channel 1's earlier run is reported late. It must not be hidden by channel 2's.
+		snap(valve(1, 1648699200, 1648700400), valve(2, 1648701000, 1648702800)),
+	} {
+		if err := l.Record(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got := l.Events(time.Unix(0, 0), time.Unix(1<<40, 0))
+	want := []Event{
+		{Valve: miyo.DeviceRef{ID: "{f223afe9}", Channel: 1}, Circuit: "{rasen}", Start: time.Unix(1648699200, 0), End: time.Unix(1648700400, 0)},
+		{Valve: miyo.DeviceRef{ID: "{f223afe9}", Channel: 2}, Circuit: "{rosen}", Start: time.Unix(1648701000, 0), End: time.Unix(1648702800, 0)},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Events() differs (-want/+got):\n%s", diff)
+	}
+}
@@ -0,0 +1,121 @@
+package irrigation
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Rates configures the flow rates of valves and the price of water.
+// Flow rates are given in liters per minute.
+type Rates struct {
+	// Valves holds the flow rate of individual valves, keyed by device reference, e.g. "{uuid};1", or by device
+	// ID for all channels of a unit.
+	Valves map[string]float64 `json:"valves"`
+	// IrrigationTypes holds the flow rate used for valves without an individual rate, keyed by
+	// CircuitParams.IrrigationType.
+	IrrigationTypes map[int]float64 `json:"irrigationTypes"`
+	// Default is the flow rate used if neither of the above applies.
+	Default float64 `json:"default"`
+	// PricePerCubicMeter is the price of 1000 liters of water.
+	PricePerCubicMeter float64 `json:"pricePerCubicMeter"`
+}
+
+// FlowRate returns the flow rate in liters per minute that applies to e.
+func (r Rates) FlowRate(e Event) float64 {
+	if rate, ok := r.Valves[e.Valve.String()]; ok {
+		return rate
+	}
+	if rate, ok := r.Valves[e.Valve.ID]; ok {
+		return rate
+	}
+	if rate, ok := r.IrrigationTypes[e.IrrigationType]; ok {
+		return rate
+	}
+	return r.Default
+}
+
+// Liters returns the amount of water used by e.
+func (r Rates) Liters(e Event) float64 {
+	return r.FlowRate(e) * e.Duration().Minutes()
+}
+
+// Period is the length of the intervals usage is aggregated in.
+type Period int
+
+const (
+	Period_Day Period = iota
+	Period_Week
+	// Period_Season aggregates by calendar year.
+	Period_Season
+)
+
+func (p Period) String() string {
+	names := map[Period]string{
+		Period_Day:    "day",
+		Period_Week:   "week",
+		Period_Season: "season",
+	}
+	if name, ok := names[p]; ok {
+		return name
+	}
+	return fmt.Sprintf("Period#%d", p)
+}
+
+// start returns the beginning of the period containing t.
+func (p Period) start(t time.Time) time.Time {
+	y, m, d := t.Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+
+	switch p {
+	case Period_Week:
+		// weeks start on Monday
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	case Period_Season:
+		return time.Date(y, time.January, 1, 0, 0, 0, 0, t.Location())
+	default:
+		return day
+	}
+}
+
+// Usage is the water used within one period.
+type Usage struct {
+	Start    time.Time
+	Period   Period
+	Duration time.Duration
+	Liters   float64
+	Cost     float64
+}
+
+// Summarize aggregates the water usage of events per period, in chronological order.
+// Periods without any events are omitted.
+func (r Rates) Summarize(events []Event, p Period) []Usage {
+	byStart := make(map[int64]*Usage)
+	for _, e := range events {
+		start := p.start(e.Start)
+		u, ok := byStart[start.Unix()]
+		if !ok {
+			u = &Usage{
+				Start:  start,
+				Period: p,
+			}
+			byStart[start.Unix()] = u
+		}
+
+		l := r.Liters(e)
+		u.Duration += e.Duration()
+		u.Liters += l
+		u.Cost += l / 1000 * r.PricePerCubicMeter
+	}
+
+	ret := make([]Usage, 0, len(byStart))
+	for _, u := range byStart {
+		ret = append(ret, *u)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Start.Before(ret[j].Start)
+	})
+
+	return ret
+}
@@ -0,0 +1,69 @@
+package irrigation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/octo/miyo-go/miyo"
+)
+
+func TestSummarize(t *testing.T) {
+	rates := Rates{
+		Valves: map[string]float64{
+			"{valve-a}": 10,
+		},
+		IrrigationTypes: map[int]float64{
+			1: 20,
+		},
+		Default:            5,
+		PricePerCubicMeter: 2,
+	}
+
+	// 2022-04-04 is a Monday.
+	day := func(d, h int) time.Time {
+		return time.Date(2022, 4, d, h, 0, 0, 0, time.UTC)
+	}
+	events := []Event{
+		{Valve: miyo.DeviceRef{ID: "{valve-a}"}, IrrigationType: 1, Start: day(4, 6), End: day(4, 6).Add(30 * time.Minute)},
+		{Valve: miyo.DeviceRef{ID: "{valve-b}"}, IrrigationType: 1, Start: day(4, 20), End: day(4, 20).Add(10 * time.Minute)},
+		{Valve: miyo.DeviceRef{ID: "{valve-c}"}, Start: day(10, 6), End: day(10, 6).Add(20 * time.Minute)},
+		{Valve: miyo.DeviceRef{ID: "{valve-a}"}, Start: day(11, 6), End: day(11, 6).Add(10 * time.Minute)},
+	}
+
+	cases := []struct {
+		period Period
+		want   []Usage
+	}{
+		{
+			period: Period_Day,
+			want: []Usage{
+				{Start: day(4, 0), Period: Period_Day, Duration: 40 * time.Minute, Liters: 500, Cost: 1},
+				{Start: day(10, 0), Period: Period_Day, Duration: 20 * time.Minute, Liters: 100, Cost: 0.2},
+				{Start: day(11, 0), Period: Period_Day, Duration: 10 * time.Minute, Liters: 100, Cost: 0.2},
+			},
+		},
+		{
+			period: Period_Week,
+			want: []Usage{
+				{Start: day(4, 0), Period: Period_Week, Duration: 60 * time.Minute, Liters: 600, Cost: 1.2},
+				{Start: day(11, 0), Period: Period_Week, Duration: 10 * time.Minute, Liters: 100, Cost: 0.2},
+			},
+		},
+		{
+			period: Period_Season,
+			want: []Usage{
+				{Start: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), Period: Period_Season, Duration: 70 * time.Minute, Liters: 700, Cost: 1.4},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.period.String(), func(t *testing.T) {
+			got := rates.Summarize(events, tc.period)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Summarize() differs (-want/+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package miyo
+
+import (
+	"sort"
+	"strings"
+)
+
+// AreaList is a list of areas as returned by Conn.Areas.
+type AreaList []Circuit
+
+// sortAreas orders areas by name, then by ID.
+func sortAreas(areas AreaList) {
+	sort.Slice(areas, func(i, j int) bool {
+		if areas[i].Name != areas[j].Name {
+			return areas[i].Name < areas[j].Name
+		}
+		return areas[i].ID < areas[j].ID
+	})
+}
+
+// ByID returns the area with the given ID.
+func (l AreaList) ByID(id string) (Circuit, bool) {
+	for _, c := range l {
+		if c.ID == id {
+			return c, true
+		}
+	}
+	return Circuit{}, false
+}
+
+// ByName returns the area with the given name. Names are compared case-insensitively.
+func (l AreaList) ByName(name string) (Circuit, bool) {
+	for _, c := range l {
+		if strings.EqualFold(c.Name, name) {
+			return c, true
+		}
+	}
+	return Circuit{}, false
+}
+
+// Find returns the area with the given ID or, failing that, name.
+func (l AreaList) Find(idOrName string) (Circuit, bool) {
+	if c, ok := l.ByID(idOrName); ok {
+		return c, true
+	}
+	return l.ByName(idOrName)
+}
+
+// BySensor returns the area using the moisture sensor with the given device ID.
+func (l AreaList) BySensor(id string) (Circuit, bool) {
+	for _, c := range l {
+		if c.Sensor == id {
+			return c, true
+		}
+	}
+	return Circuit{}, false
+}
+
+// ByValve returns the first area using the valve with the given device ID.
+// Use Topology.SharedValves to find valves used by several areas.
+func (l AreaList) ByValve(id string) (Circuit, bool) {
+	for _, c := range l {
+		if c.HasValve(id) {
+			return c, true
+		}
+	}
+	return Circuit{}, false
+}
+
+// HasValve reports whether the valve with the given device ID is one of the circuit's valves.
+func (c Circuit) HasValve(id string) bool {
+	for _, v := range c.Valves {
+		if v.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// AreaFilter reports whether an area is selected by AreaList.Filter.
+type AreaFilter func(Circuit) bool
+
+// AreaReachable selects areas whose moisture sensor is, or is not, reachable.
+func AreaReachable(reachable bool) AreaFilter {
+	return func(c Circuit) bool { return c.SensorData.State.Reachable == reachable }
+}
+
+// AreaIrrigating selects areas that are, or are not, being irrigated.
+func AreaIrrigating(irrigating bool) AreaFilter {
+	return func(c Circuit) bool { return c.State.Irrigation == irrigating }
+}
+
+// Filter returns the areas selected by all filters.
+func (l AreaList) Filter(filters ...AreaFilter) AreaList {
+	var ret AreaList
+next:
+	for _, c := range l {
+		for _, f := range filters {
+			if !f(c) {
+				continue next
+			}
+		}
+		ret = append(ret, c)
+	}
+	return ret
+}
+
+// ByID returns the device with the given ID, regardless of its channel.
+func (l DeviceList) ByID(id string) (Device, bool) {
+	return l.Lookup(DeviceRef{ID: id})
+}
+
+// DeviceFilter reports whether a device is selected by DeviceList.Filter.
+type DeviceFilter func(Device) bool
+
+// DeviceOfType selects devices of type t.
+func DeviceOfType(t DeviceType) DeviceFilter {
+	return func(d Device) bool { return d.DeviceType() == t }
+}
+
+// DeviceReachable selects devices that are, or are not, reachable.
+func DeviceReachable(reachable bool) DeviceFilter {
+	return func(d Device) bool { return d.State.Reachable == reachable }
+}
+
+// DeviceIrrigating selects valves that are open, or devices that are not open valves.
+func DeviceIrrigating(irrigating bool) DeviceFilter {
+	return func(d Device) bool {
+		open := d.DeviceType() == DeviceType_Valve && d.State.ValveStatus
+		return open == irrigating
+	}
+}
+
+// Filter returns the devices selected by all filters.
+func (l DeviceList) Filter(filters ...DeviceFilter) DeviceList {
+	var ret DeviceList
+next:
+	for _, d := range l {
+		for _, f := range filters {
+			if !f(d) {
+				continue next
+			}
+		}
+		ret = append(ret, d)
+	}
+	return ret
+}
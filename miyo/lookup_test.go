@@ -0,0 +1,95 @@
+package miyo
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAreaList(t *testing.T) {
+	areas := AreaList{
+		{ID: "{3}", Name: "Rosen", Sensor: "{sensor-b}", Valves: map[string]Valve{"0": {ID: "{valve-2}"}}},
+		{ID: "{2}", Name: "Rasen", Sensor: "{sensor-a}", Valves: map[string]Valve{"0": {ID: "{valve-1}"}},
+			State: CircuitState{Irrigation: true}, SensorData: Device{State: DeviceState{Reachable: true}}},
+		{ID: "{1}", Name: "Rosen"},
+	}
+	sortAreas(areas)
+
+	ids := func(l AreaList) []string {
+		var ret []string
+		for _, c := range l {
+			ret = append(ret, c.ID)
+		}
+		return ret
+	}
+	if diff := cmp.Diff([]string{"{2}", "{1}", "{3}"}, ids(areas)); diff != "" {
+		t.Errorf("sortAreas() differs (-want/+got):\n%s", diff)
+	}
+
+	cases := []struct {
+		name string
+		fn   func(string) (Circuit, bool)
+		arg  string
+		want string
+	}{
+		{"ByID", areas.ByID, "{3}", "{3}"},
+		{"ByID", areas.ByID, "Rasen", ""},
+		{"ByName", areas.ByName, "rasen", "{2}"},
+		{"Find", areas.Find, "{1}", "{1}"},
+		{"Find", areas.Find, "ROSEN", "{1}"},
+		{"BySensor", areas.BySensor, "{sensor-b}", "{3}"},
+		{"ByValve", areas.ByValve, "{valve-1}", "{2}"},
+		{"ByValve", areas.ByValve, "{sensor-a}", ""},
+	}
+	for _, tc := range cases {
+		c, ok := tc.fn(tc.arg)
+		if got := c.ID; got != tc.want || ok != (tc.want != "") {
+			t.Errorf("%s(%q) = %q, %v, want %q", tc.name, tc.arg, got, ok, tc.want)
+		}
+	}
+
+	if diff := cmp.Diff([]string{"{2}"}, ids(areas.Filter(AreaIrrigating(true)))); diff != "" {
+		t.Errorf("Filter(AreaIrrigating(true)) differs (-want/+got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"{1}", "{3}"}, ids(areas.Filter(AreaReachable(false), AreaIrrigating(false)))); diff != "" {
+		t.Errorf("Filter(AreaReachable(false), AreaIrrigating(false)) differs (-want/+got):\n%s", diff)
+	}
+}
+
+func TestDeviceListFilter(t *testing.T) {
+	devs := DeviceList{
+		{ID: "{sensor}", Type: "moistureOutdoor", State: DeviceState{Reachable: true}},
+		{ID: "{open}", Type: "valve", State: DeviceState{Reachable: true, ValveStatus: true}},
+		{ID: "{closed}", Type: "valve"},
+	}
+
+	ids := func(l DeviceList) []string {
+		var ret []string
+		for _, d := range l {
+			ret = append(ret, d.ID)
+		}
+		return ret
+	}
+
+	cases := []struct {
+		name    string
+		filters []DeviceFilter
+		want    []string
+	}{
+		{"no filter", nil, []string{"{sensor}", "{open}", "{closed}"}},
+		{"valves", []DeviceFilter{DeviceOfType(DeviceType_Valve)}, []string{"{open}", "{closed}"}},
+		{"reachable valves", []DeviceFilter{DeviceOfType(DeviceType_Valve), DeviceReachable(true)}, []string{"{open}"}},
+		{"unreachable", []DeviceFilter{DeviceReachable(false)}, []string{"{closed}"}},
+		{"irrigating", []DeviceFilter{DeviceIrrigating(true)}, []string{"{open}"}},
+		{"not irrigating", []DeviceFilter{DeviceIrrigating(false)}, []string{"{sensor}", "{closed}"}},
+	}
+	for _, tc := range cases {
+		if diff := cmp.Diff(tc.want, ids(devs.Filter(tc.filters...))); diff != "" {
+			t.Errorf("%s: Filter() differs (-want/+got):\n%s", tc.name, diff)
+		}
+	}
+
+	if d, ok := devs.ByID("{closed}"); !ok || d.ID != "{closed}" {
+		t.Errorf("ByID(%q) = %q, %v, want found", "{closed}", d.ID, ok)
+	}
+}
@@ -0,0 +1,2184 @@
+// Service definition for querying and controlling a MIYO Cube.
+// The messages mirror the types of the Go package github.com/octo/miyo-go/miyo.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        (unknown)
+// source: miyo/v1/miyo.proto
+
+package miyopb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// DeviceState mirrors miyo.DeviceState.
+type DeviceState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ValveInitialClose      bool   `protobuf:"varint,1,opt,name=valve_initial_close,json=valveInitialClose,proto3" json:"valve_initial_close,omitempty"`
+	ValveStatus            bool   `protobuf:"varint,2,opt,name=valve_status,json=valveStatus,proto3" json:"valve_status,omitempty"`
+	OpenValve              bool   `protobuf:"varint,3,opt,name=open_valve,json=openValve,proto3" json:"open_valve,omitempty"`
+	LastIrrigationStart    int64  `protobuf:"varint,4,opt,name=last_irrigation_start,json=lastIrrigationStart,proto3" json:"last_irrigation_start,omitempty"`
+	LastIrrigationEnd      int64  `protobuf:"varint,5,opt,name=last_irrigation_end,json=lastIrrigationEnd,proto3" json:"last_irrigation_end,omitempty"`
+	LastIrrigationDuration int64  `protobuf:"varint,6,opt,name=last_irrigation_duration,json=lastIrrigationDuration,proto3" json:"last_irrigation_duration,omitempty"`
+	Rssi                   int32  `protobuf:"varint,7,opt,name=rssi,proto3" json:"rssi,omitempty"`
+	Reachable              bool   `protobuf:"varint,8,opt,name=reachable,proto3" json:"reachable,omitempty"`
+	SolarVoltage           int32  `protobuf:"varint,9,opt,name=solar_voltage,json=solarVoltage,proto3" json:"solar_voltage,omitempty"`
+	SunWithinWeek          bool   `protobuf:"varint,10,opt,name=sun_within_week,json=sunWithinWeek,proto3" json:"sun_within_week,omitempty"`
+	LowPower               bool   `protobuf:"varint,11,opt,name=low_power,json=lowPower,proto3" json:"low_power,omitempty"`
+	OtauPossible           bool   `protobuf:"varint,12,opt,name=otau_possible,json=otauPossible,proto3" json:"otau_possible,omitempty"`
+	OtauProgress           int32  `protobuf:"varint,13,opt,name=otau_progress,json=otauProgress,proto3" json:"otau_progress,omitempty"`
+	OtauStatus             string `protobuf:"bytes,14,opt,name=otau_status,json=otauStatus,proto3" json:"otau_status,omitempty"`
+	WinterMode             bool   `protobuf:"varint,15,opt,name=winter_mode,json=winterMode,proto3" json:"winter_mode,omitempty"`
+	ChargingDurationDay    int32  `protobuf:"varint,16,opt,name=charging_duration_day,json=chargingDurationDay,proto3" json:"charging_duration_day,omitempty"`
+	Charging               bool   `protobuf:"varint,17,opt,name=charging,proto3" json:"charging,omitempty"`
+	ChargingLess           bool   `protobuf:"varint,18,opt,name=charging_less,json=chargingLess,proto3" json:"charging_less,omitempty"`
+	LastResetTime          int64  `protobuf:"varint,19,opt,name=last_reset_time,json=lastResetTime,proto3" json:"last_reset_time,omitempty"`
+	LastResetType          int32  `protobuf:"varint,20,opt,name=last_reset_type,json=lastResetType,proto3" json:"last_reset_type,omitempty"`
+	Moisture               int32  `protobuf:"varint,21,opt,name=moisture,proto3" json:"moisture,omitempty"`
+	Brightness             int32  `protobuf:"varint,22,opt,name=brightness,proto3" json:"brightness,omitempty"`
+	Temperature            int32  `protobuf:"varint,23,opt,name=temperature,proto3" json:"temperature,omitempty"`
+	Frequency              int32  `protobuf:"varint,24,opt,name=frequency,proto3" json:"frequency,omitempty"`
+	IrrigationNecessary    bool   `protobuf:"varint,25,opt,name=irrigation_necessary,json=irrigationNecessary,proto3" json:"irrigation_necessary,omitempty"`
+	IrrigationPossible     bool   `protobuf:"varint,26,opt,name=irrigation_possible,json=irrigationPossible,proto3" json:"irrigation_possible,omitempty"`
+	TemperatureOffset      int32  `protobuf:"varint,27,opt,name=temperature_offset,json=temperatureOffset,proto3" json:"temperature_offset,omitempty"`
+}
+
+func (x *DeviceState) Reset() {
+	*x = DeviceState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_miyo_v1_miyo_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeviceState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceState) ProtoMessage() {}
+
+func (x *DeviceState) ProtoReflect() protoreflect.Message {
+	mi := &file_miyo_v1_miyo_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceState.ProtoReflect.Descriptor instead.
+func (*DeviceState) Descriptor() ([]byte, []int) {
+	return file_miyo_v1_miyo_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DeviceState) GetValveInitialClose() bool {
+	if x != nil {
+		return x.ValveInitialClose
+	}
+	return false
+}
+
+func (x *DeviceState) GetValveStatus() bool {
+	if x != nil {
+		return x.ValveStatus
+	}
+	return false
+}
+
+func (x *DeviceState) GetOpenValve() bool {
+	if x != nil {
+		return x.OpenValve
+	}
+	return false
+}
+
+func (x *DeviceState) GetLastIrrigationStart() int64 {
+	if x != nil {
+		return x.LastIrrigationStart
+	}
+	return 0
+}
+
+func (x *DeviceState) GetLastIrrigationEnd() int64 {
+	if x != nil {
+		return x.LastIrrigationEnd
+	}
+	return 0
+}
+
+func (x *DeviceState) GetLastIrrigationDuration() int64 {
+	if x != nil {
+		return x.LastIrrigationDuration
+	}
+	return 0
+}
+
+func (x *DeviceState) GetRssi() int32 {
+	if x != nil {
+		return x.Rssi
+	}
+	return 0
+}
+
+func (x *DeviceState) GetReachable() bool {
+	if x != nil {
+		return x.Reachable
+	}
+	return false
+}
+
+func (x *DeviceState) GetSolarVoltage() int32 {
+	if x != nil {
+		return x.SolarVoltage
+	}
+	return 0
+}
+
+func (x *DeviceState) GetSunWithinWeek() bool {
+	if x != nil {
+		return x.SunWithinWeek
+	}
+	return false
+}
+
+func (x *DeviceState) GetLowPower() bool {
+	if x != nil {
+		return x.LowPower
+	}
+	return false
+}
+
+func (x *DeviceState) GetOtauPossible() bool {
+	if x != nil {
+		return x.OtauPossible
+	}
+	return false
+}
+
+func (x *DeviceState) GetOtauProgress() int32 {
+	if x != nil {
+		return x.OtauProgress
+	}
+	return 0
+}
+
+func (x *DeviceState) GetOtauStatus() string {
+	if x != nil {
+		return x.OtauStatus
+	}
+	return ""
+}
+
+func (x *DeviceState) GetWinterMode() bool {
+	if x != nil {
+		return x.WinterMode
+	}
+	return false
+}
+
+func (x *DeviceState) GetChargingDurationDay() int32 {
+	if x != nil {
+		return x.ChargingDurationDay
+	}
+	return 0
+}
+
+func (x *DeviceState) GetCharging() bool {
+	if x != nil {
+		return x.Charging
+	}
+	return false
+}
+
+func (x *DeviceState) GetChargingLess() bool {
+	if x != nil {
+		return x.ChargingLess
+	}
+	return false
+}
+
+func (x *DeviceState) GetLastResetTime() int64 {
+	if x != nil {
+		return x.LastResetTime
+	}
+	return 0
+}
+
+func (x *DeviceState) GetLastResetType() int32 {
+	if x != nil {
+		return x.LastResetType
+	}
+	return 0
+}
+
+func (x *DeviceState) GetMoisture() int32 {
+	if x != nil {
+		return x.Moisture
+	}
+	return 0
+}
+
+func (x *DeviceState) GetBrightness() int32 {
+	if x != nil {
+		return x.Brightness
+	}
+	return 0
+}
+
+func (x *DeviceState) GetTemperature() int32 {
+	if x != nil {
+		return x.Temperature
+	}
+	return 0
+}
+
+func (x *DeviceState) GetFrequency() int32 {
+	if x != nil {
+		return x.Frequency
+	}
+	return 0
+}
+
+func (x *DeviceState) GetIrrigationNecessary() bool {
+	if x != nil {
+		return x.IrrigationNecessary
+	}
+	return false
+}
+
+func (x *DeviceState) GetIrrigationPossible() bool {
+	if x != nil {
+		return x.IrrigationPossible
+	}
+	return false
+}
+
+func (x *DeviceState) GetTemperatureOffset() int32 {
+	if x != nil {
+		return x.TemperatureOffset
+	}
+	return 0
+}
+
+// Device mirrors miyo.Device.
+type Device struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Channel    int32        `protobuf:"varint,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	Id         string       `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Type       string       `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Firmware   string       `protobuf:"bytes,4,opt,name=firmware,proto3" json:"firmware,omitempty"`
+	Ipv6       string       `protobuf:"bytes,5,opt,name=ipv6,proto3" json:"ipv6,omitempty"`
+	LastUpdate int64        `protobuf:"varint,6,opt,name=last_update,json=lastUpdate,proto3" json:"last_update,omitempty"`
+	State      *DeviceState `protobuf:"bytes,7,opt,name=state,proto3" json:"state,omitempty"`
+	// ref is the device's key in the MIYO Cube's device list, "{uuid};channel".
+	Ref string `protobuf:"bytes,8,opt,name=ref,proto3" json:"ref,omitempty"`
+}
+
+func (x *Device) Reset() {
+	*x = Device{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_miyo_v1_miyo_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Device) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Device) ProtoMessage() {}
+
+func (x *Device) ProtoReflect() protoreflect.Message {
+	mi := &file_miyo_v1_miyo_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Device.ProtoReflect.Descriptor instead.
+func (*Device) Descriptor() ([]byte, []int) {
+	return file_miyo_v1_miyo_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Device) GetChannel() int32 {
+	if x != nil {
+		return x.Channel
+	}
+	return 0
+}
+
+func (x *Device) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Device) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Device) GetFirmware() string {
+	if x != nil {
+		return x.Firmware
+	}
+	return ""
+}
+
+func (x *Device) GetIpv6() string {
+	if x != nil {
+		return x.Ipv6
+	}
+	return ""
+}
+
+func (x *Device) GetLastUpdate() int64 {
+	if x != nil {
+		return x.LastUpdate
+	}
+	return 0
+}
+
+func (x *Device) GetState() *DeviceState {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+func (x *Device) GetRef() string {
+	if x != nil {
+		return x.Ref
+	}
+	return ""
+}
+
+// CircuitParams mirrors miyo.CircuitParams.
+type CircuitParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AutomaticMode   bool `protobuf:"varint,1,opt,name=automatic_mode,json=automaticMode,proto3" json:"automatic_mode,omitempty"`
+	ConsiderCharge  bool `protobuf:"varint,4,opt,name=consider_charge,json=considerCharge,proto3" json:"consider_charge,omitempty"`
+	ConsiderMower   bool `protobuf:"varint,5,opt,name=consider_mower,json=considerMower,proto3" json:"consider_mower,omitempty"`
+	ConsiderWeather bool `protobuf:"varint,6,opt,name=consider_weather,json=considerWeather,proto3" json:"consider_weather,omitempty"`
+	// schedule holds the irrigation windows of Day0 to Day6.
+	Schedule                []string `protobuf:"bytes,7,rep,name=schedule,proto3" json:"schedule,omitempty"`
+	IrrigationDelayForecast bool     `protobuf:"varint,8,opt,name=irrigation_delay_forecast,json=irrigationDelayForecast,proto3" json:"irrigation_delay_forecast,omitempty"`
+	IrrigationType          int32    `protobuf:"varint,9,opt,name=irrigation_type,json=irrigationType,proto3" json:"irrigation_type,omitempty"`
+	LocationType            int32    `protobuf:"varint,10,opt,name=location_type,json=locationType,proto3" json:"location_type,omitempty"`
+	PlantType               int32    `protobuf:"varint,11,opt,name=plant_type,json=plantType,proto3" json:"plant_type,omitempty"`
+	SoilType                int32    `protobuf:"varint,12,opt,name=soil_type,json=soilType,proto3" json:"soil_type,omitempty"`
+	TemperatureOffset       int32    `protobuf:"varint,13,opt,name=temperature_offset,json=temperatureOffset,proto3" json:"temperature_offset,omitempty"`
+	ValveStaggering         bool     `protobuf:"varint,14,opt,name=valve_staggering,json=valveStaggering,proto3" json:"valve_staggering,omitempty"`
+	// border_bottom_percent and border_top_percent are the moisture bounds in percent.
+	BorderBottomPercent int32 `protobuf:"varint,15,opt,name=border_bottom_percent,json=borderBottomPercent,proto3" json:"border_bottom_percent,omitempty"`
+	BorderTopPercent    int32 `protobuf:"varint,16,opt,name=border_top_percent,json=borderTopPercent,proto3" json:"border_top_percent,omitempty"`
+}
+
+func (x *CircuitParams) Reset() {
+	*x = CircuitParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_miyo_v1_miyo_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CircuitParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CircuitParams) ProtoMessage() {}
+
+func (x *CircuitParams) ProtoReflect() protoreflect.Message {
+	mi := &file_miyo_v1_miyo_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CircuitParams.ProtoReflect.Descriptor instead.
+func (*CircuitParams) Descriptor() ([]byte, []int) {
+	return file_miyo_v1_miyo_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CircuitParams) GetAutomaticMode() bool {
+	if x != nil {
+		return x.AutomaticMode
+	}
+	return false
+}
+
+func (x *CircuitParams) GetConsiderCharge() bool {
+	if x != nil {
+		return x.ConsiderCharge
+	}
+	return false
+}
+
+func (x *CircuitParams) GetConsiderMower() bool {
+	if x != nil {
+		return x.ConsiderMower
+	}
+	return false
+}
+
+func (x *CircuitParams) GetConsiderWeather() bool {
+	if x != nil {
+		return x.ConsiderWeather
+	}
+	return false
+}
+
+func (x *CircuitParams) GetSchedule() []string {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+func (x *CircuitParams) GetIrrigationDelayForecast() bool {
+	if x != nil {
+		return x.IrrigationDelayForecast
+	}
+	return false
+}
+
+func (x *CircuitParams) GetIrrigationType() int32 {
+	if x != nil {
+		return x.IrrigationType
+	}
+	return 0
+}
+
+func (x *CircuitParams) GetLocationType() int32 {
+	if x != nil {
+		return x.LocationType
+	}
+	return 0
+}
+
+func (x *CircuitParams) GetPlantType() int32 {
+	if x != nil {
+		return x.PlantType
+	}
+	return 0
+}
+
+func (x *CircuitParams) GetSoilType() int32 {
+	if x != nil {
+		return x.SoilType
+	}
+	return 0
+}
+
+func (x *CircuitParams) GetTemperatureOffset() int32 {
+	if x != nil {
+		return x.TemperatureOffset
+	}
+	return 0
+}
+
+func (x *CircuitParams) GetValveStaggering() bool {
+	if x != nil {
+		return x.ValveStaggering
+	}
+	return false
+}
+
+func (x *CircuitParams) GetBorderBottomPercent() int32 {
+	if x != nil {
+		return x.BorderBottomPercent
+	}
+	return 0
+}
+
+func (x *CircuitParams) GetBorderTopPercent() int32 {
+	if x != nil {
+		return x.BorderTopPercent
+	}
+	return 0
+}
+
+// CircuitState mirrors miyo.CircuitState.
+type CircuitState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AutomaticMode        bool  `protobuf:"varint,1,opt,name=automatic_mode,json=automaticMode,proto3" json:"automatic_mode,omitempty"`
+	ExternBlock          bool  `protobuf:"varint,2,opt,name=extern_block,json=externBlock,proto3" json:"extern_block,omitempty"`
+	Irrigation           bool  `protobuf:"varint,3,opt,name=irrigation,proto3" json:"irrigation,omitempty"`
+	IrrigationNextEnd    int64 `protobuf:"varint,4,opt,name=irrigation_next_end,json=irrigationNextEnd,proto3" json:"irrigation_next_end,omitempty"`
+	IrrigationNextStart  int64 `protobuf:"varint,5,opt,name=irrigation_next_start,json=irrigationNextStart,proto3" json:"irrigation_next_start,omitempty"`
+	ValveStaggeringIndex int32 `protobuf:"varint,6,opt,name=valve_staggering_index,json=valveStaggeringIndex,proto3" json:"valve_staggering_index,omitempty"`
+	WinterMode           bool  `protobuf:"varint,7,opt,name=winter_mode,json=winterMode,proto3" json:"winter_mode,omitempty"`
+}
+
+func (x *CircuitState) Reset() {
+	*x = CircuitState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_miyo_v1_miyo_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CircuitState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CircuitState) ProtoMessage() {}
+
+func (x *CircuitState) ProtoReflect() protoreflect.Message {
+	mi := &file_miyo_v1_miyo_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CircuitState.ProtoReflect.Descriptor instead.
+func (*CircuitState) Descriptor() ([]byte, []int) {
+	return file_miyo_v1_miyo_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CircuitState) GetAutomaticMode() bool {
+	if x != nil {
+		return x.AutomaticMode
+	}
+	return false
+}
+
+func (x *CircuitState) GetExternBlock() bool {
+	if x != nil {
+		return x.ExternBlock
+	}
+	return false
+}
+
+func (x *CircuitState) GetIrrigation() bool {
+	if x != nil {
+		return x.Irrigation
+	}
+	return false
+}
+
+func (x *CircuitState) GetIrrigationNextEnd() int64 {
+	if x != nil {
+		return x.IrrigationNextEnd
+	}
+	return 0
+}
+
+func (x *CircuitState) GetIrrigationNextStart() int64 {
+	if x != nil {
+		return x.IrrigationNextStart
+	}
+	return 0
+}
+
+func (x *CircuitState) GetValveStaggeringIndex() int32 {
+	if x != nil {
+		return x.ValveStaggeringIndex
+	}
+	return 0
+}
+
+func (x *CircuitState) GetWinterMode() bool {
+	if x != nil {
+		return x.WinterMode
+	}
+	return false
+}
+
+// SensorValve mirrors miyo.SensorValve.
+type SensorValve struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Valve   string `protobuf:"bytes,1,opt,name=valve,proto3" json:"valve,omitempty"`
+	Channel int32  `protobuf:"varint,2,opt,name=channel,proto3" json:"channel,omitempty"`
+}
+
+func (x *SensorValve) Reset() {
+	*x = SensorValve{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_miyo_v1_miyo_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SensorValve) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SensorValve) ProtoMessage() {}
+
+func (x *SensorValve) ProtoReflect() protoreflect.Message {
+	mi := &file_miyo_v1_miyo_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SensorValve.ProtoReflect.Descriptor instead.
+func (*SensorValve) Descriptor() ([]byte, []int) {
+	return file_miyo_v1_miyo_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SensorValve) GetValve() string {
+	if x != nil {
+		return x.Valve
+	}
+	return ""
+}
+
+func (x *SensorValve) GetChannel() int32 {
+	if x != nil {
+		return x.Channel
+	}
+	return 0
+}
+
+// Valve mirrors miyo.Valve.
+type Valve struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id      string  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Data    *Device `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Channel int32   `protobuf:"varint,3,opt,name=channel,proto3" json:"channel,omitempty"`
+}
+
+func (x *Valve) Reset() {
+	*x = Valve{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_miyo_v1_miyo_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Valve) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Valve) ProtoMessage() {}
+
+func (x *Valve) ProtoReflect() protoreflect.Message {
+	mi := &file_miyo_v1_miyo_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Valve.ProtoReflect.Descriptor instead.
+func (*Valve) Descriptor() ([]byte, []int) {
+	return file_miyo_v1_miyo_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Valve) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Valve) GetData() *Device {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Valve) GetChannel() int32 {
+	if x != nil {
+		return x.Channel
+	}
+	return 0
+}
+
+// Circuit mirrors miyo.Circuit.
+type Circuit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          string            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string            `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Params      *CircuitParams    `protobuf:"bytes,3,opt,name=params,proto3" json:"params,omitempty"`
+	SensorValve *SensorValve      `protobuf:"bytes,4,opt,name=sensor_valve,json=sensorValve,proto3" json:"sensor_valve,omitempty"`
+	Valves      map[string]*Valve `protobuf:"bytes,5,rep,name=valves,proto3" json:"valves,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	State       *CircuitState     `protobuf:"bytes,6,opt,name=state,proto3" json:"state,omitempty"`
+	Sensor      string            `protobuf:"bytes,7,opt,name=sensor,proto3" json:"sensor,omitempty"`
+	SensorData  *Device           `protobuf:"bytes,8,opt,name=sensor_data,json=sensorData,proto3" json:"sensor_data,omitempty"`
+}
+
+func (x *Circuit) Reset() {
+	*x = Circuit{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_miyo_v1_miyo_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Circuit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Circuit) ProtoMessage() {}
+
+func (x *Circuit) ProtoReflect() protoreflect.Message {
+	mi := &file_miyo_v1_miyo_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Circuit.ProtoReflect.Descriptor instead.
+func (*Circuit) Descriptor() ([]byte, []int) {
+	return file_miyo_v1_miyo_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Circuit) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Circuit) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Circuit) GetParams() *CircuitParams {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *Circuit) GetSensorValve() *SensorValve {
+	if x != nil {
+		return x.SensorValve
+	}
+	return nil
+}
+
+func (x *Circuit) GetValves() map[string]*Valve {
+	if x != nil {
+		return x.Valves
+	}
+	return nil
+}
+
+func (x *Circuit) GetState() *CircuitState {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+func (x *Circuit) GetSensor() string {
+	if x != nil {
+		return x.Sensor
+	}
+	return ""
+}
+
+func (x *Circuit) GetSensorData() *Device {
+	if x != nil {
+		return x.SensorData
+	}
+	return nil
+}
+
+// Snapshot mirrors miyo.Snapshot.
+type Snapshot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// time is the time of the snapshot in seconds since the Unix epoch.
+	Time     int64      `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`
+	Circuits []*Circuit `protobuf:"bytes,2,rep,name=circuits,proto3" json:"circuits,omitempty"`
+	Devices  []*Device  `protobuf:"bytes,3,rep,name=devices,proto3" json:"devices,omitempty"`
+}
+
+func (x *Snapshot) Reset() {
+	*x = Snapshot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_miyo_v1_miyo_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Snapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Snapshot) ProtoMessage() {}
+
+func (x *Snapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_miyo_v1_miyo_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Snapshot.ProtoReflect.Descriptor instead.
+func (*Snapshot) Descriptor() ([]byte, []int) {
+	return file_miyo_v1_miyo_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Snapshot) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *Snapshot) GetCircuits() []*Circuit {
+	if x != nil {
+		return x.Circuits
+	}
+	return nil
+}
+
+func (x *Snapshot) GetDevices() []*Device {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+type ListDevicesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListDevicesRequest) Reset() {
+	*x = ListDevicesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_miyo_v1_miyo_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDevicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDevicesRequest) ProtoMessage() {}
+
+func (x *ListDevicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_miyo_v1_miyo_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDevicesRequest.ProtoReflect.Descriptor instead.
+func (*ListDevicesRequest) Descriptor() ([]byte, []int) {
+	return file_miyo_v1_miyo_proto_rawDescGZIP(), []int{8}
+}
+
+type ListDevicesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Devices []*Device `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
+}
+
+func (x *ListDevicesResponse) Reset() {
+	*x = ListDevicesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_miyo_v1_miyo_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDevicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDevicesResponse) ProtoMessage() {}
+
+func (x *ListDevicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_miyo_v1_miyo_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDevicesResponse.ProtoReflect.Descriptor instead.
+func (*ListDevicesResponse) Descriptor() ([]byte, []int) {
+	return file_miyo_v1_miyo_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListDevicesResponse) GetDevices() []*Device {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+type ListCircuitsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListCircuitsRequest) Reset() {
+	*x = ListCircuitsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_miyo_v1_miyo_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListCircuitsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCircuitsRequest) ProtoMessage() {}
+
+func (x *ListCircuitsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_miyo_v1_miyo_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCircuitsRequest.ProtoReflect.Descriptor instead.
+func (*ListCircuitsRequest) Descriptor() ([]byte, []int) {
+	return file_miyo_v1_miyo_proto_rawDescGZIP(), []int{10}
+}
+
+type ListCircuitsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Circuits []*Circuit `protobuf:"bytes,1,rep,name=circuits,proto3" json:"circuits,omitempty"`
+}
+
+func (x *ListCircuitsResponse) Reset() {
+	*x = ListCircuitsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_miyo_v1_miyo_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListCircuitsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCircuitsResponse) ProtoMessage() {}
+
+func (x *ListCircuitsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_miyo_v1_miyo_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCircuitsResponse.ProtoReflect.Descriptor instead.
+func (*ListCircuitsResponse) Descriptor() ([]byte, []int) {
+	return file_miyo_v1_miyo_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListCircuitsResponse) GetCircuits() []*Circuit {
+	if x != nil {
+		return x.Circuits
+	}
+	return nil
+}
+
+type GetSnapshotRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetSnapshotRequest) Reset() {
+	*x = GetSnapshotRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_miyo_v1_miyo_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSnapshotRequest) ProtoMessage() {}
+
+func (x *GetSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_miyo_v1_miyo_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*GetSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_miyo_v1_miyo_proto_rawDescGZIP(), []int{12}
+}
+
+type SetValveRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeviceId string `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Open     bool   `protobuf:"varint,2,opt,name=open,proto3" json:"open,omitempty"`
+}
+
+func (x *SetValveRequest) Reset() {
+	*x = SetValveRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_miyo_v1_miyo_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetValveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetValveRequest) ProtoMessage() {}
+
+func (x *SetValveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_miyo_v1_miyo_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetValveRequest.ProtoReflect.Descriptor instead.
+func (*SetValveRequest) Descriptor() ([]byte, []int) {
+	return file_miyo_v1_miyo_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SetValveRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *SetValveRequest) GetOpen() bool {
+	if x != nil {
+		return x.Open
+	}
+	return false
+}
+
+type SetValveResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SetValveResponse) Reset() {
+	*x = SetValveResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_miyo_v1_miyo_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetValveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetValveResponse) ProtoMessage() {}
+
+func (x *SetValveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_miyo_v1_miyo_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetValveResponse.ProtoReflect.Descriptor instead.
+func (*SetValveResponse) Descriptor() ([]byte, []int) {
+	return file_miyo_v1_miyo_proto_rawDescGZIP(), []int{14}
+}
+
+type SetIrrigationRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CircuitId string `protobuf:"bytes,1,opt,name=circuit_id,json=circuitId,proto3" json:"circuit_id,omitempty"`
+	Irrigate  bool   `protobuf:"varint,2,opt,name=irrigate,proto3" json:"irrigate,omitempty"`
+}
+
+func (x *SetIrrigationRequest) Reset() {
+	*x = SetIrrigationRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_miyo_v1_miyo_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetIrrigationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetIrrigationRequest) ProtoMessage() {}
+
+func (x *SetIrrigationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_miyo_v1_miyo_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetIrrigationRequest.ProtoReflect.Descriptor instead.
+func (*SetIrrigationRequest) Descriptor() ([]byte, []int) {
+	return file_miyo_v1_miyo_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SetIrrigationRequest) GetCircuitId() string {
+	if x != nil {
+		return x.CircuitId
+	}
+	return ""
+}
+
+func (x *SetIrrigationRequest) GetIrrigate() bool {
+	if x != nil {
+		return x.Irrigate
+	}
+	return false
+}
+
+type SetIrrigationResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SetIrrigationResponse) Reset() {
+	*x = SetIrrigationResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_miyo_v1_miyo_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetIrrigationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetIrrigationResponse) ProtoMessage() {}
+
+func (x *SetIrrigationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_miyo_v1_miyo_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetIrrigationResponse.ProtoReflect.Descriptor instead.
+func (*SetIrrigationResponse) Descriptor() ([]byte, []int) {
+	return file_miyo_v1_miyo_proto_rawDescGZIP(), []int{16}
+}
+
+type SetAutomaticModeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CircuitId string `protobuf:"bytes,1,opt,name=circuit_id,json=circuitId,proto3" json:"circuit_id,omitempty"`
+	Enabled   bool   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+}
+
+func (x *SetAutomaticModeRequest) Reset() {
+	*x = SetAutomaticModeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_miyo_v1_miyo_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetAutomaticModeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAutomaticModeRequest) ProtoMessage() {}
+
+func (x *SetAutomaticModeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_miyo_v1_miyo_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAutomaticModeRequest.ProtoReflect.Descriptor instead.
+func (*SetAutomaticModeRequest) Descriptor() ([]byte, []int) {
+	return file_miyo_v1_miyo_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SetAutomaticModeRequest) GetCircuitId() string {
+	if x != nil {
+		return x.CircuitId
+	}
+	return ""
+}
+
+func (x *SetAutomaticModeRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+type SetAutomaticModeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SetAutomaticModeResponse) Reset() {
+	*x = SetAutomaticModeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_miyo_v1_miyo_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetAutomaticModeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAutomaticModeResponse) ProtoMessage() {}
+
+func (x *SetAutomaticModeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_miyo_v1_miyo_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAutomaticModeResponse.ProtoReflect.Descriptor instead.
+func (*SetAutomaticModeResponse) Descriptor() ([]byte, []int) {
+	return file_miyo_v1_miyo_proto_rawDescGZIP(), []int{18}
+}
+
+type SetScheduleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CircuitId string `protobuf:"bytes,1,opt,name=circuit_id,json=circuitId,proto3" json:"circuit_id,omitempty"`
+	// day is the index of the day, i.e. 0 sets Day0.
+	Day int32 `protobuf:"varint,2,opt,name=day,proto3" json:"day,omitempty"`
+	// windows has the same format as Day0, e.g. "06:30-09:00;19:00-22:00".
+	Windows string `protobuf:"bytes,3,opt,name=windows,proto3" json:"windows,omitempty"`
+}
+
+func (x *SetScheduleRequest) Reset() {
+	*x = SetScheduleRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_miyo_v1_miyo_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetScheduleRequest) ProtoMessage() {}
+
+func (x *SetScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_miyo_v1_miyo_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetScheduleRequest.ProtoReflect.Descriptor instead.
+func (*SetScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_miyo_v1_miyo_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SetScheduleRequest) GetCircuitId() string {
+	if x != nil {
+		return x.CircuitId
+	}
+	return ""
+}
+
+func (x *SetScheduleRequest) GetDay() int32 {
+	if x != nil {
+		return x.Day
+	}
+	return 0
+}
+
+func (x *SetScheduleRequest) GetWindows() string {
+	if x != nil {
+		return x.Windows
+	}
+	return ""
+}
+
+type SetScheduleResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SetScheduleResponse) Reset() {
+	*x = SetScheduleResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_miyo_v1_miyo_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetScheduleResponse) ProtoMessage() {}
+
+func (x *SetScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_miyo_v1_miyo_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetScheduleResponse.ProtoReflect.Descriptor instead.
+func (*SetScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_miyo_v1_miyo_proto_rawDescGZIP(), []int{20}
+}
+
+type WatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_miyo_v1_miyo_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRequest) ProtoMessage() {}
+
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_miyo_v1_miyo_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return file_miyo_v1_miyo_proto_rawDescGZIP(), []int{21}
+}
+
+var File_miyo_v1_miyo_proto protoreflect.FileDescriptor
+
+var file_miyo_v1_miyo_proto_rawDesc = []byte{
+	0x0a, 0x12, 0x6d, 0x69, 0x79, 0x6f, 0x2f, 0x76, 0x31, 0x2f, 0x6d, 0x69, 0x79, 0x6f, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x6d, 0x69, 0x79, 0x6f, 0x2e, 0x76, 0x31, 0x22, 0x99, 0x08,
+	0x0a, 0x0b, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x2e, 0x0a,
+	0x13, 0x76, 0x61, 0x6c, 0x76, 0x65, 0x5f, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x63,
+	0x6c, 0x6f, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x76, 0x61, 0x6c, 0x76,
+	0x65, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x12, 0x21, 0x0a,
+	0x0c, 0x76, 0x61, 0x6c, 0x76, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0b, 0x76, 0x61, 0x6c, 0x76, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x1d, 0x0a, 0x0a, 0x6f, 0x70, 0x65, 0x6e, 0x5f, 0x76, 0x61, 0x6c, 0x76, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x6e, 0x56, 0x61, 0x6c, 0x76, 0x65, 0x12,
+	0x32, 0x0a, 0x15, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x69, 0x72, 0x72, 0x69, 0x67, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x13,
+	0x6c, 0x61, 0x73, 0x74, 0x49, 0x72, 0x72, 0x69, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74,
+	0x61, 0x72, 0x74, 0x12, 0x2e, 0x0a, 0x13, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x69, 0x72, 0x72, 0x69,
+	0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x65, 0x6e, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x11, 0x6c, 0x61, 0x73, 0x74, 0x49, 0x72, 0x72, 0x69, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x45, 0x6e, 0x64, 0x12, 0x38, 0x0a, 0x18, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x69, 0x72, 0x72, 0x69,
+	0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x16, 0x6c, 0x61, 0x73, 0x74, 0x49, 0x72, 0x72, 0x69, 0x67,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a,
+	0x04, 0x72, 0x73, 0x73, 0x69, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x72, 0x73, 0x73,
+	0x69, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x72, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x12,
+	0x23, 0x0a, 0x0d, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x5f, 0x76, 0x6f, 0x6c, 0x74, 0x61, 0x67, 0x65,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x56, 0x6f, 0x6c,
+	0x74, 0x61, 0x67, 0x65, 0x12, 0x26, 0x0a, 0x0f, 0x73, 0x75, 0x6e, 0x5f, 0x77, 0x69, 0x74, 0x68,
+	0x69, 0x6e, 0x5f, 0x77, 0x65, 0x65, 0x6b, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x73,
+	0x75, 0x6e, 0x57, 0x69, 0x74, 0x68, 0x69, 0x6e, 0x57, 0x65, 0x65, 0x6b, 0x12, 0x1b, 0x0a, 0x09,
+	0x6c, 0x6f, 0x77, 0x5f, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x6c, 0x6f, 0x77, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x6f, 0x74, 0x61,
+	0x75, 0x5f, 0x70, 0x6f, 0x73, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0c, 0x6f, 0x74, 0x61, 0x75, 0x50, 0x6f, 0x73, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x12, 0x23,
+	0x0a, 0x0d, 0x6f, 0x74, 0x61, 0x75, 0x5f, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18,
+	0x0d, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x6f, 0x74, 0x61, 0x75, 0x50, 0x72, 0x6f, 0x67, 0x72,
+	0x65, 0x73, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6f, 0x74, 0x61, 0x75, 0x5f, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6f, 0x74, 0x61, 0x75, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x77, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x5f, 0x6d,
+	0x6f, 0x64, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x77, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x32, 0x0a, 0x15, 0x63, 0x68, 0x61, 0x72, 0x67, 0x69, 0x6e,
+	0x67, 0x5f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x64, 0x61, 0x79, 0x18, 0x10,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x13, 0x63, 0x68, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x67, 0x44, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x61,
+	0x72, 0x67, 0x69, 0x6e, 0x67, 0x18, 0x11, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x63, 0x68, 0x61,
+	0x72, 0x67, 0x69, 0x6e, 0x67, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x68, 0x61, 0x72, 0x67, 0x69, 0x6e,
+	0x67, 0x5f, 0x6c, 0x65, 0x73, 0x73, 0x18, 0x12, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x63, 0x68,
+	0x61, 0x72, 0x67, 0x69, 0x6e, 0x67, 0x4c, 0x65, 0x73, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6c, 0x61,
+	0x73, 0x74, 0x5f, 0x72, 0x65, 0x73, 0x65, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x13, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0d, 0x6c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x73, 0x65, 0x74, 0x54, 0x69,
+	0x6d, 0x65, 0x12, 0x26, 0x0a, 0x0f, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x72, 0x65, 0x73, 0x65, 0x74,
+	0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x14, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x6c, 0x61, 0x73,
+	0x74, 0x52, 0x65, 0x73, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x6f,
+	0x69, 0x73, 0x74, 0x75, 0x72, 0x65, 0x18, 0x15, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6d, 0x6f,
+	0x69, 0x73, 0x74, 0x75, 0x72, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x62, 0x72, 0x69, 0x67, 0x68, 0x74,
+	0x6e, 0x65, 0x73, 0x73, 0x18, 0x16, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x62, 0x72, 0x69, 0x67,
+	0x68, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x17, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x74, 0x65, 0x6d,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x66, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x18, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x66, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x31, 0x0a, 0x14, 0x69, 0x72, 0x72, 0x69, 0x67, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x65, 0x63, 0x65, 0x73, 0x73, 0x61, 0x72, 0x79, 0x18, 0x19,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x13, 0x69, 0x72, 0x72, 0x69, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x4e, 0x65, 0x63, 0x65, 0x73, 0x73, 0x61, 0x72, 0x79, 0x12, 0x2f, 0x0a, 0x13, 0x69, 0x72, 0x72,
+	0x69, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x6f, 0x73, 0x73, 0x69, 0x62, 0x6c, 0x65,
+	0x18, 0x1a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x69, 0x72, 0x72, 0x69, 0x67, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x50, 0x6f, 0x73, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x12, 0x2d, 0x0a, 0x12, 0x74, 0x65,
+	0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74,
+	0x18, 0x1b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x22, 0xd5, 0x01, 0x0a, 0x06, 0x44, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x72, 0x6d, 0x77, 0x61, 0x72, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x72, 0x6d, 0x77, 0x61, 0x72, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x69, 0x70, 0x76, 0x36, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x69, 0x70,
+	0x76, 0x36, 0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x12, 0x2a, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x69, 0x79, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12,
+	0x10, 0x0a, 0x03, 0x72, 0x65, 0x66, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x72, 0x65,
+	0x66, 0x22, 0xf6, 0x04, 0x0a, 0x0d, 0x43, 0x69, 0x72, 0x63, 0x75, 0x69, 0x74, 0x50, 0x61, 0x72,
+	0x61, 0x6d, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x61, 0x75, 0x74, 0x6f, 0x6d, 0x61, 0x74, 0x69, 0x63,
+	0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x61, 0x75, 0x74,
+	0x6f, 0x6d, 0x61, 0x74, 0x69, 0x63, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f,
+	0x6e, 0x73, 0x69, 0x64, 0x65, 0x72, 0x5f, 0x63, 0x68, 0x61, 0x72, 0x67, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x73, 0x69, 0x64, 0x65, 0x72, 0x43, 0x68, 0x61,
+	0x72, 0x67, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x73, 0x69, 0x64, 0x65, 0x72, 0x5f,
+	0x6d, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x63, 0x6f, 0x6e,
+	0x73, 0x69, 0x64, 0x65, 0x72, 0x4d, 0x6f, 0x77, 0x65, 0x72, 0x12, 0x29, 0x0a, 0x10, 0x63, 0x6f,
+	0x6e, 0x73, 0x69, 0x64, 0x65, 0x72, 0x5f, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x63, 0x6f, 0x6e, 0x73, 0x69, 0x64, 0x65, 0x72, 0x57, 0x65,
+	0x61, 0x74, 0x68, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c,
+	0x65, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c,
+	0x65, 0x12, 0x3a, 0x0a, 0x19, 0x69, 0x72, 0x72, 0x69, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x64, 0x65, 0x6c, 0x61, 0x79, 0x5f, 0x66, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x17, 0x69, 0x72, 0x72, 0x69, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x44, 0x65, 0x6c, 0x61, 0x79, 0x46, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x12, 0x27, 0x0a,
+	0x0f, 0x69, 0x72, 0x72, 0x69, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x69, 0x72, 0x72, 0x69, 0x67, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x6c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70,
+	0x6c, 0x61, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x09, 0x70, 0x6c, 0x61, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x6f,
+	0x69, 0x6c, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x73,
+	0x6f, 0x69, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x12, 0x2d, 0x0a, 0x12, 0x74, 0x65, 0x6d, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x0d, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x11, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x29, 0x0a, 0x10, 0x76, 0x61, 0x6c, 0x76, 0x65, 0x5f,
+	0x73, 0x74, 0x61, 0x67, 0x67, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0f, 0x76, 0x61, 0x6c, 0x76, 0x65, 0x53, 0x74, 0x61, 0x67, 0x67, 0x65, 0x72, 0x69, 0x6e,
+	0x67, 0x12, 0x32, 0x0a, 0x15, 0x62, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x62, 0x6f, 0x74, 0x74,
+	0x6f, 0x6d, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x13, 0x62, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x6f, 0x74, 0x74, 0x6f, 0x6d, 0x50, 0x65,
+	0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x2c, 0x0a, 0x12, 0x62, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f,
+	0x74, 0x6f, 0x70, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x10, 0x62, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x6f, 0x70, 0x50, 0x65, 0x72, 0x63,
+	0x65, 0x6e, 0x74, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x4a, 0x04, 0x08, 0x03, 0x10, 0x04, 0x52,
+	0x0d, 0x62, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x62, 0x6f, 0x74, 0x74, 0x6f, 0x6d, 0x52, 0x0a,
+	0x62, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x74, 0x6f, 0x70, 0x22, 0xb3, 0x02, 0x0a, 0x0c, 0x43,
+	0x69, 0x72, 0x63, 0x75, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x61,
+	0x75, 0x74, 0x6f, 0x6d, 0x61, 0x74, 0x69, 0x63, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0d, 0x61, 0x75, 0x74, 0x6f, 0x6d, 0x61, 0x74, 0x69, 0x63, 0x4d, 0x6f,
+	0x64, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x5f, 0x62, 0x6c, 0x6f,
+	0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x72, 0x72, 0x69, 0x67, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x69, 0x72, 0x72, 0x69, 0x67,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x13, 0x69, 0x72, 0x72, 0x69, 0x67, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x65, 0x6e, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x11, 0x69, 0x72, 0x72, 0x69, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x65,
+	0x78, 0x74, 0x45, 0x6e, 0x64, 0x12, 0x32, 0x0a, 0x15, 0x69, 0x72, 0x72, 0x69, 0x67, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x13, 0x69, 0x72, 0x72, 0x69, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x4e, 0x65, 0x78, 0x74, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x34, 0x0a, 0x16, 0x76, 0x61, 0x6c,
+	0x76, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x67, 0x67, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x14, 0x76, 0x61, 0x6c, 0x76, 0x65,
+	0x53, 0x74, 0x61, 0x67, 0x67, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12,
+	0x1f, 0x0a, 0x0b, 0x77, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x77, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x4d, 0x6f, 0x64, 0x65,
+	0x22, 0x3d, 0x0a, 0x0b, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x56, 0x61, 0x6c, 0x76, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x76, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x76, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x22,
+	0x56, 0x0a, 0x05, 0x56, 0x61, 0x6c, 0x76, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x23, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6d, 0x69, 0x79, 0x6f, 0x2e, 0x76, 0x31,
+	0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x18, 0x0a,
+	0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07,
+	0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x22, 0x8e, 0x03, 0x0a, 0x07, 0x43, 0x69, 0x72, 0x63,
+	0x75, 0x69, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2e, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6d, 0x69, 0x79, 0x6f, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x69, 0x72, 0x63, 0x75, 0x69, 0x74, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52,
+	0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x37, 0x0a, 0x0c, 0x73, 0x65, 0x6e, 0x73, 0x6f,
+	0x72, 0x5f, 0x76, 0x61, 0x6c, 0x76, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x6d, 0x69, 0x79, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x56, 0x61,
+	0x6c, 0x76, 0x65, 0x52, 0x0b, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x56, 0x61, 0x6c, 0x76, 0x65,
+	0x12, 0x34, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x76, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1c, 0x2e, 0x6d, 0x69, 0x79, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x69, 0x72, 0x63, 0x75,
+	0x69, 0x74, 0x2e, 0x56, 0x61, 0x6c, 0x76, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06,
+	0x76, 0x61, 0x6c, 0x76, 0x65, 0x73, 0x12, 0x2b, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x69, 0x79, 0x6f, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x69, 0x72, 0x63, 0x75, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x12, 0x30, 0x0a, 0x0b, 0x73,
+	0x65, 0x6e, 0x73, 0x6f, 0x72, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0f, 0x2e, 0x6d, 0x69, 0x79, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x52, 0x0a, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x44, 0x61, 0x74, 0x61, 0x1a, 0x49, 0x0a,
+	0x0b, 0x56, 0x61, 0x6c, 0x76, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x24,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e,
+	0x6d, 0x69, 0x79, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x76, 0x65, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x77, 0x0a, 0x08, 0x53, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x2c, 0x0a, 0x08, 0x63, 0x69, 0x72, 0x63,
+	0x75, 0x69, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x69, 0x79,
+	0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x69, 0x72, 0x63, 0x75, 0x69, 0x74, 0x52, 0x08, 0x63, 0x69,
+	0x72, 0x63, 0x75, 0x69, 0x74, 0x73, 0x12, 0x29, 0x0a, 0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6d, 0x69, 0x79, 0x6f, 0x2e, 0x76,
+	0x31, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x73, 0x22, 0x14, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x40, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x44,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29,
+	0x0a, 0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0f, 0x2e, 0x6d, 0x69, 0x79, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x52, 0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x22, 0x15, 0x0a, 0x13, 0x4c, 0x69, 0x73,
+	0x74, 0x43, 0x69, 0x72, 0x63, 0x75, 0x69, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x22, 0x44, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x69, 0x72, 0x63, 0x75, 0x69, 0x74, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x08, 0x63, 0x69, 0x72, 0x63,
+	0x75, 0x69, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x69, 0x79,
+	0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x69, 0x72, 0x63, 0x75, 0x69, 0x74, 0x52, 0x08, 0x63, 0x69,
+	0x72, 0x63, 0x75, 0x69, 0x74, 0x73, 0x22, 0x14, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x53, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x42, 0x0a, 0x0f,
+	0x53, 0x65, 0x74, 0x56, 0x61, 0x6c, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1b, 0x0a, 0x09, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x6f, 0x70, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6f, 0x70, 0x65, 0x6e,
+	0x22, 0x12, 0x0a, 0x10, 0x53, 0x65, 0x74, 0x56, 0x61, 0x6c, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x51, 0x0a, 0x14, 0x53, 0x65, 0x74, 0x49, 0x72, 0x72, 0x69, 0x67,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
+	0x63, 0x69, 0x72, 0x63, 0x75, 0x69, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x63, 0x69, 0x72, 0x63, 0x75, 0x69, 0x74, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x69,
+	0x72, 0x72, 0x69, 0x67, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x69,
+	0x72, 0x72, 0x69, 0x67, 0x61, 0x74, 0x65, 0x22, 0x17, 0x0a, 0x15, 0x53, 0x65, 0x74, 0x49, 0x72,
+	0x72, 0x69, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x52, 0x0a, 0x17, 0x53, 0x65, 0x74, 0x41, 0x75, 0x74, 0x6f, 0x6d, 0x61, 0x74, 0x69, 0x63,
+	0x4d, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x63,
+	0x69, 0x72, 0x63, 0x75, 0x69, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x63, 0x69, 0x72, 0x63, 0x75, 0x69, 0x74, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e,
+	0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61,
+	0x62, 0x6c, 0x65, 0x64, 0x22, 0x1a, 0x0a, 0x18, 0x53, 0x65, 0x74, 0x41, 0x75, 0x74, 0x6f, 0x6d,
+	0x61, 0x74, 0x69, 0x63, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x5f, 0x0a, 0x12, 0x53, 0x65, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x69, 0x72, 0x63, 0x75, 0x69,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x69, 0x72, 0x63,
+	0x75, 0x69, 0x74, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x61, 0x79, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x03, 0x64, 0x61, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x77, 0x69, 0x6e, 0x64, 0x6f,
+	0x77, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77,
+	0x73, 0x22, 0x15, 0x0a, 0x13, 0x53, 0x65, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x0e, 0x0a, 0x0c, 0x57, 0x61, 0x74, 0x63,
+	0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x32, 0xc5, 0x04, 0x0a, 0x04, 0x4d, 0x69, 0x79,
+	0x6f, 0x12, 0x48, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73,
+	0x12, 0x1b, 0x2e, 0x6d, 0x69, 0x79, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e,
+	0x6d, 0x69, 0x79, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x76, 0x69,
+	0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4b, 0x0a, 0x0c, 0x4c,
+	0x69, 0x73, 0x74, 0x43, 0x69, 0x72, 0x63, 0x75, 0x69, 0x74, 0x73, 0x12, 0x1c, 0x2e, 0x6d, 0x69,
+	0x79, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x69, 0x72, 0x63, 0x75, 0x69,
+	0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x6d, 0x69, 0x79, 0x6f,
+	0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x69, 0x72, 0x63, 0x75, 0x69, 0x74, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x53,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x1b, 0x2e, 0x6d, 0x69, 0x79, 0x6f, 0x2e, 0x76,
+	0x31, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x6d, 0x69, 0x79, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x3f, 0x0a, 0x08, 0x53, 0x65, 0x74, 0x56, 0x61,
+	0x6c, 0x76, 0x65, 0x12, 0x18, 0x2e, 0x6d, 0x69, 0x79, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65,
+	0x74, 0x56, 0x61, 0x6c, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e,
+	0x6d, 0x69, 0x79, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x56, 0x61, 0x6c, 0x76, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e, 0x0a, 0x0d, 0x53, 0x65, 0x74, 0x49,
+	0x72, 0x72, 0x69, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x2e, 0x6d, 0x69, 0x79, 0x6f,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x49, 0x72, 0x72, 0x69, 0x67, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x6d, 0x69, 0x79, 0x6f, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x49, 0x72, 0x72, 0x69, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x10, 0x53, 0x65, 0x74, 0x41,
+	0x75, 0x74, 0x6f, 0x6d, 0x61, 0x74, 0x69, 0x63, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x20, 0x2e, 0x6d,
+	0x69, 0x79, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x41, 0x75, 0x74, 0x6f, 0x6d, 0x61,
+	0x74, 0x69, 0x63, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21,
+	0x2e, 0x6d, 0x69, 0x79, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x41, 0x75, 0x74, 0x6f,
+	0x6d, 0x61, 0x74, 0x69, 0x63, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x48, 0x0a, 0x0b, 0x53, 0x65, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
+	0x12, 0x1b, 0x2e, 0x6d, 0x69, 0x79, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x53, 0x63,
+	0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e,
+	0x6d, 0x69, 0x79, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64,
+	0x75, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x05, 0x57,
+	0x61, 0x74, 0x63, 0x68, 0x12, 0x15, 0x2e, 0x6d, 0x69, 0x79, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x57,
+	0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x6d, 0x69,
+	0x79, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x30, 0x01,
+	0x42, 0x25, 0x5a, 0x23, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f,
+	0x63, 0x74, 0x6f, 0x2f, 0x6d, 0x69, 0x79, 0x6f, 0x2d, 0x67, 0x6f, 0x2f, 0x6d, 0x69, 0x79, 0x6f,
+	0x2f, 0x6d, 0x69, 0x79, 0x6f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_miyo_v1_miyo_proto_rawDescOnce sync.Once
+	file_miyo_v1_miyo_proto_rawDescData = file_miyo_v1_miyo_proto_rawDesc
+)
+
+func file_miyo_v1_miyo_proto_rawDescGZIP() []byte {
+	file_miyo_v1_miyo_proto_rawDescOnce.Do(func() {
+		file_miyo_v1_miyo_proto_rawDescData = protoimpl.X.CompressGZIP(file_miyo_v1_miyo_proto_rawDescData)
+	})
+	return file_miyo_v1_miyo_proto_rawDescData
+}
+
+var file_miyo_v1_miyo_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_miyo_v1_miyo_proto_goTypes = []interface{}{
+	(*DeviceState)(nil),              // 0: miyo.v1.DeviceState
+	(*Device)(nil),                   // 1: miyo.v1.Device
+	(*CircuitParams)(nil),            // 2: miyo.v1.CircuitParams
+	(*CircuitState)(nil),             // 3: miyo.v1.CircuitState
+	(*SensorValve)(nil),              // 4: miyo.v1.SensorValve
+	(*Valve)(nil),                    // 5: miyo.v1.Valve
+	(*Circuit)(nil),                  // 6: miyo.v1.Circuit
+	(*Snapshot)(nil),                 // 7: miyo.v1.Snapshot
+	(*ListDevicesRequest)(nil),       // 8: miyo.v1.ListDevicesRequest
+	(*ListDevicesResponse)(nil),      // 9: miyo.v1.ListDevicesResponse
+	(*ListCircuitsRequest)(nil),      // 10: miyo.v1.ListCircuitsRequest
+	(*ListCircuitsResponse)(nil),     // 11: miyo.v1.ListCircuitsResponse
+	(*GetSnapshotRequest)(nil),       // 12: miyo.v1.GetSnapshotRequest
+	(*SetValveRequest)(nil),          // 13: miyo.v1.SetValveRequest
+	(*SetValveResponse)(nil),         // 14: miyo.v1.SetValveResponse
+	(*SetIrrigationRequest)(nil),     // 15: miyo.v1.SetIrrigationRequest
+	(*SetIrrigationResponse)(nil),    // 16: miyo.v1.SetIrrigationResponse
+	(*SetAutomaticModeRequest)(nil),  // 17: miyo.v1.SetAutomaticModeRequest
+	(*SetAutomaticModeResponse)(nil), // 18: miyo.v1.SetAutomaticModeResponse
+	(*SetScheduleRequest)(nil),       // 19: miyo.v1.SetScheduleRequest
+	(*SetScheduleResponse)(nil),      // 20: miyo.v1.SetScheduleResponse
+	(*WatchRequest)(nil),             // 21: miyo.v1.WatchRequest
+	nil,                              // 22: miyo.v1.Circuit.ValvesEntry
+}
+var file_miyo_v1_miyo_proto_depIdxs = []int32{
+	0,  // 0: miyo.v1.Device.state:type_name -> miyo.v1.DeviceState
+	1,  // 1: miyo.v1.Valve.data:type_name -> miyo.v1.Device
+	2,  // 2: miyo.v1.Circuit.params:type_name -> miyo.v1.CircuitParams
+	4,  // 3: miyo.v1.Circuit.sensor_valve:type_name -> miyo.v1.SensorValve
+	22, // 4: miyo.v1.Circuit.valves:type_name -> miyo.v1.Circuit.ValvesEntry
+	3,  // 5: miyo.v1.Circuit.state:type_name -> miyo.v1.CircuitState
+	1,  // 6: miyo.v1.Circuit.sensor_data:type_name -> miyo.v1.Device
+	6,  // 7: miyo.v1.Snapshot.circuits:type_name -> miyo.v1.Circuit
+	1,  // 8: miyo.v1.Snapshot.devices:type_name -> miyo.v1.Device
+	1,  // 9: miyo.v1.ListDevicesResponse.devices:type_name -> miyo.v1.Device
+	6,  // 10: miyo.v1.ListCircuitsResponse.circuits:type_name -> miyo.v1.Circuit
+	5,  // 11: miyo.v1.Circuit.ValvesEntry.value:type_name -> miyo.v1.Valve
+	8,  // 12: miyo.v1.Miyo.ListDevices:input_type -> miyo.v1.ListDevicesRequest
+	10, // 13: miyo.v1.Miyo.ListCircuits:input_type -> miyo.v1.ListCircuitsRequest
+	12, // 14: miyo.v1.Miyo.GetSnapshot:input_type -> miyo.v1.GetSnapshotRequest
+	13, // 15: miyo.v1.Miyo.SetValve:input_type -> miyo.v1.SetValveRequest
+	15, // 16: miyo.v1.Miyo.SetIrrigation:input_type -> miyo.v1.SetIrrigationRequest
+	17, // 17: miyo.v1.Miyo.SetAutomaticMode:input_type -> miyo.v1.SetAutomaticModeRequest
+	19, // 18: miyo.v1.Miyo.SetSchedule:input_type -> miyo.v1.SetScheduleRequest
+	21, // 19: miyo.v1.Miyo.Watch:input_type -> miyo.v1.WatchRequest
+	9,  // 20: miyo.v1.Miyo.ListDevices:output_type -> miyo.v1.ListDevicesResponse
+	11, // 21: miyo.v1.Miyo.ListCircuits:output_type -> miyo.v1.ListCircuitsResponse
+	7,  // 22: miyo.v1.Miyo.GetSnapshot:output_type -> miyo.v1.Snapshot
+	14, // 23: miyo.v1.Miyo.SetValve:output_type -> miyo.v1.SetValveResponse
+	16, // 24: miyo.v1.Miyo.SetIrrigation:output_type -> miyo.v1.SetIrrigationResponse
+	18, // 25: miyo.v1.Miyo.SetAutomaticMode:output_type -> miyo.v1.SetAutomaticModeResponse
+	20, // 26: miyo.v1.Miyo.SetSchedule:output_type -> miyo.v1.SetScheduleResponse
+	7,  // 27: miyo.v1.Miyo.Watch:output_type -> miyo.v1.Snapshot
+	20, // [20:28] is the sub-list for method output_type
+	12, // [12:20] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_miyo_v1_miyo_proto_init() }
+func file_miyo_v1_miyo_proto_init() {
+	if File_miyo_v1_miyo_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_miyo_v1_miyo_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeviceState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_miyo_v1_miyo_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Device); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_miyo_v1_miyo_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CircuitParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_miyo_v1_miyo_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CircuitState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_miyo_v1_miyo_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SensorValve); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_miyo_v1_miyo_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Valve); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_miyo_v1_miyo_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Circuit); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_miyo_v1_miyo_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Snapshot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_miyo_v1_miyo_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDevicesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_miyo_v1_miyo_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDevicesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_miyo_v1_miyo_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListCircuitsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_miyo_v1_miyo_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListCircuitsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_miyo_v1_miyo_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSnapshotRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_miyo_v1_miyo_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetValveRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_miyo_v1_miyo_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetValveResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_miyo_v1_miyo_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetIrrigationRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_miyo_v1_miyo_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetIrrigationResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_miyo_v1_miyo_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetAutomaticModeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_miyo_v1_miyo_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetAutomaticModeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_miyo_v1_miyo_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetScheduleRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_miyo_v1_miyo_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetScheduleResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_miyo_v1_miyo_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_miyo_v1_miyo_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   23,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_miyo_v1_miyo_proto_goTypes,
+		DependencyIndexes: file_miyo_v1_miyo_proto_depIdxs,
+		MessageInfos:      file_miyo_v1_miyo_proto_msgTypes,
+	}.Build()
+	File_miyo_v1_miyo_proto = out.File
+	file_miyo_v1_miyo_proto_rawDesc = nil
+	file_miyo_v1_miyo_proto_goTypes = nil
+	file_miyo_v1_miyo_proto_depIdxs = nil
+}
@@ -0,0 +1,397 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package miyopb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// MiyoClient is the client API for Miyo service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type MiyoClient interface {
+	// ListDevices returns all valves and moisture sensors.
+	ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error)
+	// ListCircuits returns all irrigation areas.
+	ListCircuits(ctx context.Context, in *ListCircuitsRequest, opts ...grpc.CallOption) (*ListCircuitsResponse, error)
+	// GetSnapshot returns the state of all devices and circuits.
+	GetSnapshot(ctx context.Context, in *GetSnapshotRequest, opts ...grpc.CallOption) (*Snapshot, error)
+	// SetValve opens or closes a valve.
+	SetValve(ctx context.Context, in *SetValveRequest, opts ...grpc.CallOption) (*SetValveResponse, error)
+	// SetIrrigation starts or stops irrigating a circuit.
+	SetIrrigation(ctx context.Context, in *SetIrrigationRequest, opts ...grpc.CallOption) (*SetIrrigationResponse, error)
+	// SetAutomaticMode enables or disables the automatic irrigation of a circuit.
+	SetAutomaticMode(ctx context.Context, in *SetAutomaticModeRequest, opts ...grpc.CallOption) (*SetAutomaticModeResponse, error)
+	// SetSchedule changes the irrigation windows of one day of a circuit's schedule.
+	SetSchedule(ctx context.Context, in *SetScheduleRequest, opts ...grpc.CallOption) (*SetScheduleResponse, error)
+	// Watch sends the current snapshot and then a new snapshot whenever the state of a device or circuit changes.
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Miyo_WatchClient, error)
+}
+
+type miyoClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMiyoClient(cc grpc.ClientConnInterface) MiyoClient {
+	return &miyoClient{cc}
+}
+
+func (c *miyoClient) ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error) {
+	out := new(ListDevicesResponse)
+	err := c.cc.Invoke(ctx, "/miyo.v1.Miyo/ListDevices", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miyoClient) ListCircuits(ctx context.Context, in *ListCircuitsRequest, opts ...grpc.CallOption) (*ListCircuitsResponse, error) {
+	out := new(ListCircuitsResponse)
+	err := c.cc.Invoke(ctx, "/miyo.v1.Miyo/ListCircuits", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miyoClient) GetSnapshot(ctx context.Context, in *GetSnapshotRequest, opts ...grpc.CallOption) (*Snapshot, error) {
+	out := new(Snapshot)
+	err := c.cc.Invoke(ctx, "/miyo.v1.Miyo/GetSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miyoClient) SetValve(ctx context.Context, in *SetValveRequest, opts ...grpc.CallOption) (*SetValveResponse, error) {
+	out := new(SetValveResponse)
+	err := c.cc.Invoke(ctx, "/miyo.v1.Miyo/SetValve", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miyoClient) SetIrrigation(ctx context.Context, in *SetIrrigationRequest, opts ...grpc.CallOption) (*SetIrrigationResponse, error) {
+	out := new(SetIrrigationResponse)
+	err := c.cc.Invoke(ctx, "/miyo.v1.Miyo/SetIrrigation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miyoClient) SetAutomaticMode(ctx context.Context, in *SetAutomaticModeRequest, opts ...grpc.CallOption) (*SetAutomaticModeResponse, error) {
+	out := new(SetAutomaticModeResponse)
+	err := c.cc.Invoke(ctx, "/miyo.v1.Miyo/SetAutomaticMode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miyoClient) SetSchedule(ctx context.Context, in *SetScheduleRequest, opts ...grpc.CallOption) (*SetScheduleResponse, error) {
+	out := new(SetScheduleResponse)
+	err := c.cc.Invoke(ctx, "/miyo.v1.Miyo/SetSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miyoClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Miyo_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Miyo_ServiceDesc.Streams[0], "/miyo.v1.Miyo/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &miyoWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Miyo_WatchClient interface {
+	Recv() (*Snapshot, error)
+	grpc.ClientStream
+}
+
+type miyoWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *miyoWatchClient) Recv() (*Snapshot, error) {
+	m := new(Snapshot)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// MiyoServer is the server API for Miyo service.
+// All implementations must embed UnimplementedMiyoServer
+// for forward compatibility
+type MiyoServer interface {
+	// ListDevices returns all valves and moisture sensors.
+	ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error)
+	// ListCircuits returns all irrigation areas.
+	ListCircuits(context.Context, *ListCircuitsRequest) (*ListCircuitsResponse, error)
+	// GetSnapshot returns the state of all devices and circuits.
+	GetSnapshot(context.Context, *GetSnapshotRequest) (*Snapshot, error)
+	// SetValve opens or closes a valve.
+	SetValve(context.Context, *SetValveRequest) (*SetValveResponse, error)
+	// SetIrrigation starts or stops irrigating a circuit.
+	SetIrrigation(context.Context, *SetIrrigationRequest) (*SetIrrigationResponse, error)
+	// SetAutomaticMode enables or disables the automatic irrigation of a circuit.
+	SetAutomaticMode(context.Context, *SetAutomaticModeRequest) (*SetAutomaticModeResponse, error)
+	// SetSchedule changes the irrigation windows of one day of a circuit's schedule.
+	SetSchedule(context.Context, *SetScheduleRequest) (*SetScheduleResponse, error)
+	// Watch sends the current snapshot and then a new snapshot whenever the state of a device or circuit changes.
+	Watch(*WatchRequest, Miyo_WatchServer) error
+	mustEmbedUnimplementedMiyoServer()
+}
+
+// UnimplementedMiyoServer must be embedded to have forward compatible implementations.
+type UnimplementedMiyoServer struct {
+}
+
+func (UnimplementedMiyoServer) ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDevices not implemented")
+}
+func (UnimplementedMiyoServer) ListCircuits(context.Context, *ListCircuitsRequest) (*ListCircuitsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCircuits not implemented")
+}
+func (UnimplementedMiyoServer) GetSnapshot(context.Context, *GetSnapshotRequest) (*Snapshot, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSnapshot not implemented")
+}
+func (UnimplementedMiyoServer) SetValve(context.Context, *SetValveRequest) (*SetValveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetValve not implemented")
+}
+func (UnimplementedMiyoServer) SetIrrigation(context.Context, *SetIrrigationRequest) (*SetIrrigationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetIrrigation not implemented")
+}
+func (UnimplementedMiyoServer) SetAutomaticMode(context.Context, *SetAutomaticModeRequest) (*SetAutomaticModeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAutomaticMode not implemented")
+}
+func (UnimplementedMiyoServer) SetSchedule(context.Context, *SetScheduleRequest) (*SetScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetSchedule not implemented")
+}
+func (UnimplementedMiyoServer) Watch(*WatchRequest, Miyo_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedMiyoServer) mustEmbedUnimplementedMiyoServer() {}
+
+// UnsafeMiyoServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MiyoServer will
+// result in compilation errors.
+type UnsafeMiyoServer interface {
+	mustEmbedUnimplementedMiyoServer()
+}
+
+func RegisterMiyoServer(s grpc.ServiceRegistrar, srv MiyoServer) {
+	s.RegisterService(&Miyo_ServiceDesc, srv)
+}
+
+func _Miyo_ListDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiyoServer).ListDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/miyo.v1.Miyo/ListDevices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiyoServer).ListDevices(ctx, req.(*ListDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Miyo_ListCircuits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCircuitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiyoServer).ListCircuits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/miyo.v1.Miyo/ListCircuits",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiyoServer).ListCircuits(ctx, req.(*ListCircuitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Miyo_GetSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiyoServer).GetSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/miyo.v1.Miyo/GetSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiyoServer).GetSnapshot(ctx, req.(*GetSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Miyo_SetValve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetValveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiyoServer).SetValve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/miyo.v1.Miyo/SetValve",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiyoServer).SetValve(ctx, req.(*SetValveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Miyo_SetIrrigation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetIrrigationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiyoServer).SetIrrigation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/miyo.v1.Miyo/SetIrrigation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiyoServer).SetIrrigation(ctx, req.(*SetIrrigationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Miyo_SetAutomaticMode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAutomaticModeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiyoServer).SetAutomaticMode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/miyo.v1.Miyo/SetAutomaticMode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiyoServer).SetAutomaticMode(ctx, req.(*SetAutomaticModeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Miyo_SetSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiyoServer).SetSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/miyo.v1.Miyo/SetSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiyoServer).SetSchedule(ctx, req.(*SetScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Miyo_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MiyoServer).Watch(m, &miyoWatchServer{stream})
+}
+
+type Miyo_WatchServer interface {
+	Send(*Snapshot) error
+	grpc.ServerStream
+}
+
+type miyoWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *miyoWatchServer) Send(m *Snapshot) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Miyo_ServiceDesc is the grpc.ServiceDesc for Miyo service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Miyo_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "miyo.v1.Miyo",
+	HandlerType: (*MiyoServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListDevices",
+			Handler:    _Miyo_ListDevices_Handler,
+		},
+		{
+			MethodName: "ListCircuits",
+			Handler:    _Miyo_ListCircuits_Handler,
+		},
+		{
+			MethodName: "GetSnapshot",
+			Handler:    _Miyo_GetSnapshot_Handler,
+		},
+		{
+			MethodName: "SetValve",
+			Handler:    _Miyo_SetValve_Handler,
+		},
+		{
+			MethodName: "SetIrrigation",
+			Handler:    _Miyo_SetIrrigation_Handler,
+		},
+		{
+			MethodName: "SetAutomaticMode",
+			Handler:    _Miyo_SetAutomaticMode_Handler,
+		},
+		{
+			MethodName: "SetSchedule",
+			Handler:    _Miyo_SetSchedule_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _Miyo_Watch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "miyo/v1/miyo.proto",
+}
@@ -0,0 +1,122 @@
+package miyo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DeviceRef identifies a device. The API keys devices as "{uuid};channel", e.g.
+// "{f223afe9-f8b9-46ae-8dcc-a868e96f2d2b};1".
+type DeviceRef struct {
+	ID string
+	// Channel is the device's channel. Zero means "any channel".
+	Channel int
+}
+
+// ParseDeviceRef parses a reference of the form "{uuid};channel" or "{uuid}".
+func ParseDeviceRef(s string) (DeviceRef, error) {
+	id, channel := s, ""
+	if i := strings.LastIndex(s, ";"); i != -1 {
+		id, channel = s[:i], s[i+1:]
+	}
+
+	if !strings.HasPrefix(id, "{") || !strings.HasSuffix(id, "}") {
+		return DeviceRef{}, fmt.Errorf("invalid device reference %q: ID must be enclosed in curly braces", s)
+	}
+
+	ref := DeviceRef{ID: id}
+	if channel != "" {
+		n, err := strconv.Atoi(channel)
+		if err != nil || n < 0 {
+			return DeviceRef{}, fmt.Errorf("invalid device reference %q: invalid channel %q", s, channel)
+		}
+		ref.Channel = n
+	}
+	return ref, nil
+}
+
+// String formats the reference as "{uuid};channel". The channel is omitted if it is zero.
+func (r DeviceRef) String() string {
+	if r.Channel == 0 {
+		return r.ID
+	}
+	return fmt.Sprintf("%s;%d", r.ID, r.Channel)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (r DeviceRef) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (r *DeviceRef) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*r = DeviceRef{}
+		return nil
+	}
+
+	ref, err := ParseDeviceRef(string(text))
+	if err != nil {
+		return err
+	}
+	*r = ref
+	return nil
+}
+
+// Matches reports whether r refers to other. A zero channel matches every channel.
+func (r DeviceRef) Matches(other DeviceRef) bool {
+	return r.ID == other.ID && (r.Channel == 0 || other.Channel == 0 || r.Channel == other.Channel)
+}
+
+// Ref returns the reference of the valve's device.
+//
+// Note that Valve.Data.Channel is not the channel of the device reference.
+func (v Valve) Ref() DeviceRef {
+	return DeviceRef{ID: v.ID, Channel: v.Channel}
+}
+
+// Ref returns the reference of the valve's device.
+func (v SensorValve) Ref() DeviceRef {
+	return DeviceRef{ID: v.Valve, Channel: v.Channel}
+}
+
+// SensorRef returns the reference of the circuit's moisture sensor. The circuit doesn't report the sensor's
+// channel, so the reference matches every channel.
+func (c Circuit) SensorRef() DeviceRef {
+	return DeviceRef{ID: c.Sensor}
+}
+
+// Reference returns d.Ref or, if it is not set, e.g. because the device wasn't returned by Conn.Devices, a
+// reference built from the device's ID and channel.
+func (d Device) Reference() DeviceRef {
+	if d.Ref.ID == "" {
+		return DeviceRef{ID: d.ID, Channel: d.Channel}
+	}
+	return d.Ref
+}
+
+// Lookup returns the device referred to by ref.
+func (l DeviceList) Lookup(ref DeviceRef) (Device, bool) {
+	for _, d := range l {
+		if ref.Matches(d.Reference()) {
+			return d, true
+		}
+	}
+	return Device{}, false
+}
+
+// Device returns the device referred to by ref.
+func (c *Conn) Device(ctx context.Context, ref DeviceRef) (Device, error) {
+	devs, err := c.Devices(ctx)
+	if err != nil {
+		return Device{}, err
+	}
+
+	d, ok := devs.Lookup(ref)
+	if !ok {
+		return Device{}, fmt.Errorf("device %s not found", ref)
+	}
+	return d, nil
+}
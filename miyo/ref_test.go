@@ -0,0 +1,131 @@
+package miyo
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+)
+
+func TestParseDeviceRef(t *testing.T) {
+	cases := []struct {
+		in      string
+		want    DeviceRef
+		wantErr bool
+	}{
+		{
+			in:   "{f223afe9-f8b9-46ae-8dcc-a868e96f2d2b};1",
+			want: DeviceRef{ID: "{f223afe9-f8b9-46ae-8dcc-a868e96f2d2b}", Channel: 1},
+		},
+		{
+			in:   "{f223afe9-f8b9-46ae-8dcc-a868e96f2d2b}",
+			want: DeviceRef{ID: "{f223afe9-f8b9-46ae-8dcc-a868e96f2d2b}"},
+		},
+		{in: "f223afe9-f8b9-46ae-8dcc-a868e96f2d2b;1", wantErr: true},
+		{in: "{f223afe9-f8b9-46ae-8dcc-a868e96f2d2b};one", wantErr: true},
+		{in: "{f223afe9-f8b9-46ae-8dcc-a868e96f2d2b};-1", wantErr: true},
+		{in: "", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		got, err := ParseDeviceRef(tc.in)
+		if gotErr := err != nil; gotErr != tc.wantErr {
+			t.Errorf("ParseDeviceRef(%q) = %v, want error %v", tc.in, err, tc.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("ParseDeviceRef(%q) = %#v, want %#v", tc.in, got, tc.want)
+		}
+		if got.String() != tc.in {
+			t.Errorf("ParseDeviceRef(%q).String() = %q, want %q", tc.in, got.String(), tc.in)
+		}
+	}
+}
+
+func TestDeviceRefMatches(t *testing.T) {
+	a := DeviceRef{ID: "{a}", Channel: 1}
+	cases := []struct {
+		other DeviceRef
+		want  bool
+	}{
+		{DeviceRef{ID: "{a}", Channel: 1}, true},
+		{DeviceRef{ID: "{a}"}, true},
+		{DeviceRef{ID: "{a}", Channel: 2}, false},
+		{DeviceRef{ID: "{b}", Channel: 1}, false},
+	}
+
+	for _, tc := range cases {
+		if got := a.Matches(tc.other); got != tc.want {
+			t.Errorf("%v.Matches(%v) = %v, want %v", a, tc.other, got, tc.want)
+		}
+	}
+}
+
+func TestDeviceRefJSON(t *testing.T) {
+	in := map[DeviceRef]bool{{ID: "{a}", Channel: 1}: true}
+
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), `{"{a};1":true}`; got != want {
+		t.Errorf("json.Marshal() = %s, want %s", got, want)
+	}
+
+	var got map[DeviceRef]bool
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !got[DeviceRef{ID: "{a}", Channel: 1}] {
+		t.Errorf("json.Unmarshal(%s) = %v, want %v", data, got, in)
+	}
+}
+
+// TestLookupValves joins the valves of the test circuit to the entries of the device list.
+func TestLookupValves(t *testing.T) {
+	var (
+		dar deviceAllResponse
+		car circuitAllResponse
+	)
+	for file, v := range map[string]interface{}{
+		"testdata/device-all.json":  &dar,
+		"testdata/circuit-all.json": &car,
+	} {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal(data, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	devs := dar.devices()
+	for i := 1; i < len(devs); i++ {
+		if devs[i-1].Ref.String() >= devs[i].Ref.String() {
+			t.Errorf("devices are not ordered: %v >= %v", devs[i-1].Ref, devs[i].Ref)
+		}
+	}
+
+	for _, c := range car.Params.Circuits {
+		for key, v := range c.Valves {
+			d, ok := devs.Lookup(v.Ref())
+			if !ok {
+				t.Errorf("%s: valve %q (%v) not found", c.Name, key, v.Ref())
+				continue
+			}
+			if d.DeviceType() != DeviceType_Valve {
+				t.Errorf("%s: valve %q resolves to device of type %v", c.Name, key, d.DeviceType())
+			}
+		}
+
+		if c.Sensor == "" {
+			continue
+		}
+		if d, ok := devs.Lookup(c.SensorRef()); !ok || d.DeviceType() != DeviceType_MoistureSensor {
+			t.Errorf("%s: Lookup(%v) = %v, %v, want moisture sensor", c.Name, c.SensorRef(), d.Ref, ok)
+		}
+	}
+}
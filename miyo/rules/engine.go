@@ -0,0 +1,292 @@
+package rules
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/octo/miyo-go/miyo"
+)
+
+// Controller is the subset of *miyo.Conn used by the engine.
+type Controller interface {
+	SetIrrigation(ctx context.Context, circuitID string, irrigate bool) error
+}
+
+// Event describes an action taken (or, in dry-run mode, not taken) by the engine.
+type Event struct {
+	Time time.Time
+	// Rule is the name of the rule that triggered the action.
+	// It is empty for irrigation stopped after a rule's duration.
+	Rule    string
+	Circuit miyo.Circuit
+	Action  Action
+	DryRun  bool
+	Err     error
+}
+
+func (e Event) String() string {
+	s := fmt.Sprintf("%s %s", e.Action, e.Circuit.Name)
+	if e.Rule != "" {
+		s += fmt.Sprintf(" (rule %q)", e.Rule)
+	} else {
+		s += " (duration elapsed)"
+	}
+	if e.DryRun {
+		s += " [dry run]"
+	}
+	if e.Err != nil {
+		s += fmt.Sprintf(": %v", e.Err)
+	}
+	return s
+}
+
+// Engine evaluates rules against snapshots of the MIYO Cube.
+// Rules are only evaluated when a snapshot is passed to the engine, so the poll interval determines how precisely
+// durations are kept.
+type Engine struct {
+	Rules      []Rule
+	Controller Controller
+	// DryRun reports actions without executing them.
+	DryRun bool
+	// StateFile, if not empty, is the file pending stops and the rules' cooldowns and daily counts are written to.
+	// Call Restore after a restart, so that irrigation started by a rule before the restart is still stopped.
+	StateFile string
+
+	mu    sync.Mutex
+	state map[string]*ruleState
+	// stops holds the time irrigation started by a rule is to be stopped, keyed by circuit ID.
+	stops map[string]time.Time
+}
+
+type ruleState struct {
+	Last  time.Time `json:"last"`
+	Day   string    `json:"day"`
+	Count int       `json:"count"`
+}
+
+// savedState is the content of Engine.StateFile.
+type savedState struct {
+	Rules map[string]*ruleState `json:"rules"`
+	Stops map[string]time.Time  `json:"stops"`
+}
+
+// Restore reads the pending stops and rule state from StateFile. A missing file is not an error.
+func (e *Engine) Restore() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.init()
+	if e.StateFile == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(e.StateFile)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var saved savedState
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return fmt.Errorf("%s: %w", e.StateFile, err)
+	}
+	for name, st := range saved.Rules {
+		e.state[name] = st
+	}
+	for id, stop := range saved.Stops {
+		e.stops[id] = stop
+	}
+	return nil
+}
+
+// save writes the pending stops and rule state to StateFile. The file is replaced atomically.
+func (e *Engine) save() error {
+	if e.StateFile == "" || e.DryRun {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(savedState{Rules: e.state, Stops: e.stops}, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := e.StateFile + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, e.StateFile)
+}
+
+func (e *Engine) init() {
+	if e.state == nil {
+		e.state = make(map[string]*ruleState)
+		e.stops = make(map[string]time.Time)
+	}
+}
+
+// Update evaluates all rules and logs the resulting events.
+// Its signature allows it to be used as callback for miyo.Conn.Poll.
+func (e *Engine) Update(snap miyo.Snapshot) error {
+	for _, ev := range e.Evaluate(context.Background(), snap) {
+		log.Print(ev)
+	}
+	return nil
+}
+
+// Evaluate evaluates all rules against snap, using snap.Time as the current time, and executes the triggered
+// actions. Irrigation started by a rule is stopped once its duration has elapsed. If stopping fails, it is
+// retried by the next call.
+func (e *Engine) Evaluate(ctx context.Context, snap miyo.Snapshot) []Event {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.init()
+	now := snap.Time
+	changed := false
+
+	var events []Event
+	for _, c := range snap.Areas {
+		stop, ok := e.stops[c.ID]
+		if !ok || now.Before(stop) {
+			continue
+		}
+		ev := e.execute(ctx, now, "", c, Action_Stop)
+		events = append(events, ev)
+		if ev.Err == nil {
+			delete(e.stops, c.ID)
+			changed = true
+		}
+	}
+
+	for _, r := range e.Rules {
+		for _, c := range snap.Areas {
+			if !r.matches(c) || !e.ready(r, now) {
+				continue
+			}
+
+			ok, err := conditionsMet(r, c, now)
+			if err != nil {
+				events = append(events, Event{Time: now, Rule: r.Name, Circuit: c, Action: r.Action, DryRun: e.DryRun, Err: err})
+				continue
+			}
+			if !ok {
+				continue
+			}
+
+			ev := e.execute(ctx, now, r.Name, c, r.Action)
+			events = append(events, ev)
+			if ev.Err != nil {
+				continue
+			}
+
+			e.record(r, now)
+			switch r.Action {
+			case Action_Irrigate:
+				e.stops[c.ID] = now.Add(r.Duration)
+			case Action_Stop:
+				delete(e.stops, c.ID)
+			}
+			changed = true
+		}
+	}
+
+	if changed {
+		if err := e.save(); err != nil {
+			log.Printf("saving pending stops: %v", err)
+		}
+	}
+
+	return events
+}
+
+// ready reports whether the rule's cooldown has elapsed and its daily limit has not been reached.
+func (e *Engine) ready(r Rule, now time.Time) bool {
+	st, ok := e.state[r.Name]
+	if !ok {
+		return true
+	}
+
+	if r.Cooldown > 0 && now.Sub(st.Last) < r.Cooldown {
+		return false
+	}
+	if r.MaxPerDay > 0 && st.Day == now.Format("2006-01-02") && st.Count >= r.MaxPerDay {
+		return false
+	}
+	return true
+}
+
+func (e *Engine) record(r Rule, now time.Time) {
+	st, ok := e.state[r.Name]
+	if !ok {
+		st = &ruleState{}
+		e.state[r.Name] = st
+	}
+
+	day := now.Format("2006-01-02")
+	if st.Day != day {
+		st.Day = day
+		st.Count = 0
+	}
+	st.Last = now
+	st.Count++
+}
+
+func conditionsMet(r Rule, c miyo.Circuit, now time.Time) (bool, error) {
+	if r.InSchedule {
+		ok, err := c.Params.InSchedule(now)
+		if err != nil {
+			return false, fmt.Errorf("schedule of %q: %w", c.Name, err)
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+
+	if r.Between != "" {
+		windows, err := miyo.ParseWindows(r.Between)
+		if err != nil {
+			return false, err
+		}
+		inWindow := false
+		for _, w := range windows {
+			if w.Contains(now) {
+				inWindow = true
+				break
+			}
+		}
+		if !inWindow {
+			return false, nil
+		}
+	}
+
+	metrics := Metrics(c)
+	for _, cond := range r.When {
+		if !cond.eval(metrics) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (e *Engine) execute(ctx context.Context, now time.Time, rule string, c miyo.Circuit, a Action) Event {
+	ev := Event{
+		Time:    now,
+		Rule:    rule,
+		Circuit: c,
+		Action:  a,
+		DryRun:  e.DryRun,
+	}
+	if e.DryRun {
+		return ev
+	}
+
+	ev.Err = e.Controller.SetIrrigation(ctx, c.ID, a == Action_Irrigate)
+	return ev
+}
@@ -0,0 +1,226 @@
+package rules
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/octo/miyo-go/miyo"
+)
+
+type fakeController struct {
+	calls []string
+	// err is returned by SetIrrigation, if set.
+	err error
+}
+
+func (c *fakeController) SetIrrigation(_ context.Context, id string, irrigate bool) error {
+	c.calls = append(c.calls, fmt.Sprintf("%s %v", id, irrigate))
+	return c.err
+}
+
+const rulesYAML = `
+rules:
+  - name: rosen-hot-day
+    circuit: rosen
+    when:
+      - moisture < 35
+      - temperature > 25
+    inSchedule: true
+    action: irrigate
+    duration: 10m
+    cooldown: 1h
+    maxPerDay: 2
+`
+
+func TestEngine(t *testing.T) {
+	rules, err := Parse([]byte(rulesYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctrl := &fakeController{}
+	e := &Engine{
+		Rules:      rules,
+		Controller: ctrl,
+	}
+
+	snap := func(t time.Time, moisture, temperature int) miyo.Snapshot {
+		return miyo.Snapshot{
+			Time: t,
+			Areas: []miyo.Circuit{
+				{
+					ID:     "{b85746b6}",
+					Name:   "Rosen",
+					Params: miyo.CircuitParams{Day0: "06:00-22:00"},
+					SensorData: miyo.Device{
+						State: miyo.DeviceState{
+							Reachable:   true,
+							Moisture:    moisture,
+							Temperature: temperature,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	// 2022-04-04 is a Monday.
+	at := func(hour, min int) time.Time {
+		return time.Date(2022, 4, 4, hour, min, 0, 0, time.UTC)
+	}
+
+	steps := []struct {
+		snap miyo.Snapshot
+		want []string
+	}{
+		{snap(at(5, 0), 30, 30), nil},  // outside of schedule
+		{snap(at(10, 0), 40, 30), nil}, // too wet
+		{snap(at(10, 5), 30, 20), nil}, // too cold
+		{snap(at(10, 10), 30, 30), []string{"irrigate Rosen (rule \"rosen-hot-day\")"}},
+		{snap(at(10, 15), 30, 30), nil}, // cooldown
+		{snap(at(10, 20), 30, 30), []string{"stop Rosen (duration elapsed)"}},
+		{snap(at(11, 10), 30, 30), []string{"irrigate Rosen (rule \"rosen-hot-day\")"}},
+		{snap(at(11, 20), 30, 30), []string{"stop Rosen (duration elapsed)"}},
+		{snap(at(12, 30), 30, 30), nil}, // daily limit
+	}
+
+	for i, step := range steps {
+		var got []string
+		for _, ev := range e.Evaluate(context.Background(), step.snap) {
+			got = append(got, ev.String())
+		}
+		if diff := cmp.Diff(step.want, got); diff != "" {
+			t.Errorf("step %d: events differ (-want/+got):\n%s", i, diff)
+		}
+	}
+
+	wantCalls := []string{
+		"{b85746b6} true",
+		"{b85746b6} false",
+		"{b85746b6} true",
+		"{b85746b6} false",
+	}
+	if diff := cmp.Diff(wantCalls, ctrl.calls); diff != "" {
+		t.Errorf("calls differ (-want/+got):\n%s", diff)
+	}
+}
+
+func TestEngineStop(t *testing.T) {
+	rules, err := Parse([]byte(rulesYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	snap := func(t time.Time) miyo.Snapshot {
+		return miyo.Snapshot{
+			Time: t,
+			Areas: []miyo.Circuit{
+				{
+					ID:         "{b85746b6}",
+					Name:       "Rosen",
+					Params:     miyo.CircuitParams{Day0: "06:00-22:00"},
+					SensorData: miyo.Device{State: miyo.DeviceState{Reachable: true, Moisture: 30, Temperature: 30}},
+				},
+			},
+		}
+	}
+	at := func(hour, min int) time.Time {
+		return time.Date(2022, 4, 4, hour, min, 0, 0, time.UTC)
+	}
+	evaluate := func(e *Engine, ts time.Time) []string {
+		var got []string
+		for _, ev := range e.Evaluate(context.Background(), snap(ts)) {
+			got = append(got, ev.String())
+		}
+		return got
+	}
+
+	stateFile := filepath.Join(t.TempDir(), "state.json")
+	ctrl := &fakeController{}
+	e := &Engine{Rules: rules, Controller: ctrl, StateFile: stateFile}
+	evaluate(e, at(10, 0))
+
+	// automation is restarted during the irrigation. The new engine still stops it and keeps the rule's cooldown.
+	e = &Engine{Rules: rules, Controller: ctrl, StateFile: stateFile}
+	if err := e.Restore(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Stopping fails and is retried.
+	ctrl.err = errors.New("cube unreachable")
+	want := []string{"stop Rosen (duration elapsed): cube unreachable"}
+	if diff := cmp.Diff(want, evaluate(e, at(10, 10))); diff != "" {
+		t.Errorf("events differ (-want/+got):\n%s", diff)
+	}
+	ctrl.err = nil
+	want = []string{"stop Rosen (duration elapsed)"}
+	if diff := cmp.Diff(want, evaluate(e, at(10, 11))); diff != "" {
+		t.Errorf("events differ (-want/+got):\n%s", diff)
+	}
+
+	wantCalls := []string{
+		"{b85746b6} true",
+		"{b85746b6} false",
+		"{b85746b6} false",
+	}
+	if diff := cmp.Diff(wantCalls, ctrl.calls); diff != "" {
+		t.Errorf("calls differ (-want/+got):\n%s", diff)
+	}
+}
+
+func TestEngineDryRun(t *testing.T) {
+	rules, err := Parse([]byte(rulesYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctrl := &fakeController{}
+	e := &Engine{
+		Rules:      rules,
+		Controller: ctrl,
+		DryRun:     true,
+	}
+
+	events := e.Evaluate(context.Background(), miyo.Snapshot{
+		Time: time.Date(2022, 4, 4, 12, 0, 0, 0, time.UTC),
+		Areas: []miyo.Circuit{
+			{
+				Name:       "Rosen",
+				Params:     miyo.CircuitParams{Day0: "06:00-22:00"},
+				SensorData: miyo.Device{State: miyo.DeviceState{Reachable: true, Moisture: 10, Temperature: 30}},
+			},
+		},
+	})
+
+	if len(events) != 1 || !events[0].DryRun {
+		t.Errorf("Evaluate() = %v, want one dry run event", events)
+	}
+	if len(ctrl.calls) != 0 {
+		t.Errorf("controller was called in dry-run mode: %v", ctrl.calls)
+	}
+}
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		name string
+		yaml string
+	}{
+		{"unknown metric", "rules: [{name: a, circuit: b, when: ['humidity < 3'], action: stop}]"},
+		{"unknown operator", "rules: [{name: a, circuit: b, when: ['moisture ~ 3'], action: stop}]"},
+		{"unknown action", "rules: [{name: a, circuit: b, action: water}]"},
+		{"missing duration", "rules: [{name: a, circuit: b, action: irrigate}]"},
+		{"duplicate name", "rules: [{name: a, circuit: b, action: stop}, {name: a, circuit: c, action: stop}]"},
+		{"invalid window", "rules: [{name: a, circuit: b, action: stop, between: '10:00'}]"},
+	}
+
+	for _, tc := range cases {
+		if _, err := Parse([]byte(tc.yaml)); err == nil {
+			t.Errorf("%s: Parse() succeeded, want error", tc.name)
+		}
+	}
+}
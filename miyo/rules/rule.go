@@ -0,0 +1,252 @@
+// Package rules implements user defined irrigation rules on top of the MIYO Cube's automatic mode.
+//
+// Rules are usually read from a YAML file:
+//
+//	rules:
+//	  - name: rosen-hot-day
+//	    circuit: Rosen
+//	    when:
+//	      - moisture < 35
+//	      - temperature > 25
+//	    inSchedule: true
+//	    action: irrigate
+//	    duration: 10m
+//	    cooldown: 2h
+//	    maxPerDay: 2
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/octo/miyo-go/miyo"
+	"gopkg.in/yaml.v3"
+)
+
+// Rule triggers an action on a circuit when all of its conditions are met.
+type Rule struct {
+	Name string `yaml:"name"`
+	// Circuit is the name or ID of the circuit the rule applies to.
+	Circuit string `yaml:"circuit"`
+	// When holds conditions that all have to be true, e.g. "moisture < 35".
+	When []Condition `yaml:"when"`
+	// InSchedule restricts the rule to the irrigation windows configured for the circuit (Day0 to Day6).
+	InSchedule bool `yaml:"inSchedule"`
+	// Between restricts the rule to time windows, e.g. "06:00-09:00;19:00-22:00".
+	Between string `yaml:"between"`
+	Action  Action `yaml:"action"`
+	// Duration is the time after which irrigation started by the rule is stopped.
+	Duration time.Duration `yaml:"duration"`
+	// Cooldown is the minimum time between two actions of the rule.
+	Cooldown time.Duration `yaml:"cooldown"`
+	// MaxPerDay limits the number of actions per day. Zero means no limit.
+	MaxPerDay int `yaml:"maxPerDay"`
+}
+
+// validate checks the rule for errors that don't depend on the state of the MIYO Cube.
+func (r Rule) validate() error {
+	if r.Name == "" {
+		return errors.New("missing name")
+	}
+	if r.Circuit == "" {
+		return errors.New("missing circuit")
+	}
+	if _, err := miyo.ParseWindows(r.Between); err != nil {
+		return fmt.Errorf("between: %w", err)
+	}
+	if r.Action == Action_Irrigate && r.Duration <= 0 {
+		return errors.New("irrigate requires a duration")
+	}
+	if r.MaxPerDay < 0 {
+		return errors.New("maxPerDay must not be negative")
+	}
+	return nil
+}
+
+// matches reports whether the rule applies to circuit c.
+func (r Rule) matches(c miyo.Circuit) bool {
+	return r.Circuit == c.ID || strings.EqualFold(r.Circuit, c.Name)
+}
+
+// Parse parses rules from YAML and validates them.
+func Parse(data []byte) ([]Rule, error) {
+	var file struct {
+		Rules []Rule `yaml:"rules"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool)
+	for i, r := range file.Rules {
+		if err := r.validate(); err != nil {
+			return nil, fmt.Errorf("rule #%d (%q): %w", i, r.Name, err)
+		}
+		if names[r.Name] {
+			return nil, fmt.Errorf("rule #%d: duplicate name %q", i, r.Name)
+		}
+		names[r.Name] = true
+	}
+
+	return file.Rules, nil
+}
+
+// Action is what a rule does when its conditions are met.
+type Action int
+
+const (
+	// Action_Irrigate starts irrigating the circuit for the rule's duration.
+	Action_Irrigate Action = iota
+	// Action_Stop stops irrigating the circuit.
+	Action_Stop
+)
+
+var actionNames = map[Action]string{
+	Action_Irrigate: "irrigate",
+	Action_Stop:     "stop",
+}
+
+func (a Action) String() string {
+	if name, ok := actionNames[a]; ok {
+		return name
+	}
+	return fmt.Sprintf("Action#%d", a)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (a *Action) UnmarshalText(text []byte) error {
+	for action, name := range actionNames {
+		if name == string(text) {
+			*a = action
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid action %q", text)
+}
+
+// Metrics returns the values conditions are evaluated against.
+// Boolean states are 0 or 1. Sensor values are missing if the circuit's sensor is unreachable.
+func Metrics(c miyo.Circuit) map[string]float64 {
+	ret := map[string]float64{
+		"irrigation":    boolValue(c.State.Irrigation),
+		"automaticMode": boolValue(c.State.AutomaticMode),
+		"externBlock":   boolValue(c.State.ExternBlock),
+		"winterMode":    boolValue(c.State.WinterMode),
+	}
+
+	s := c.SensorData.State
+	ret["reachable"] = boolValue(s.Reachable)
+	if !s.Reachable {
+		return ret
+	}
+
+	ret["moisture"] = float64(s.Moisture)
+	ret["temperature"] = float64(s.Temperature)
+	ret["brightness"] = float64(s.Brightness)
+	ret["rssi"] = float64(s.RSSI)
+	ret["lowPower"] = boolValue(s.LowPower)
+	ret["irrigationNecessary"] = boolValue(s.IrrigationNecessary)
+	ret["irrigationPossible"] = boolValue(s.IrrigationPossible)
+
+	return ret
+}
+
+var knownMetrics = map[string]bool{
+	"irrigation":          true,
+	"automaticMode":       true,
+	"externBlock":         true,
+	"winterMode":          true,
+	"reachable":           true,
+	"moisture":            true,
+	"temperature":         true,
+	"brightness":          true,
+	"rssi":                true,
+	"lowPower":            true,
+	"irrigationNecessary": true,
+	"irrigationPossible":  true,
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// Condition compares a metric to a constant, e.g. "moisture < 35".
+type Condition struct {
+	Metric string
+	Op     string
+	Value  float64
+}
+
+func (c Condition) String() string {
+	return fmt.Sprintf("%s %s %v", c.Metric, c.Op, c.Value)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *Condition) UnmarshalText(text []byte) error {
+	fields := strings.Fields(string(text))
+	if len(fields) != 3 {
+		return fmt.Errorf("invalid condition %q: want \"<metric> <op> <value>\"", text)
+	}
+
+	if !knownMetrics[fields[0]] {
+		return fmt.Errorf("invalid condition %q: unknown metric %q", text, fields[0])
+	}
+
+	switch fields[1] {
+	case "<", "<=", ">", ">=", "==", "!=":
+	default:
+		return fmt.Errorf("invalid condition %q: unknown operator %q", text, fields[1])
+	}
+
+	var value float64
+	switch fields[2] {
+	case "true":
+		value = 1
+	case "false":
+		value = 0
+	default:
+		var err error
+		value, err = strconv.ParseFloat(fields[2], 64)
+		if err != nil {
+			return fmt.Errorf("invalid condition %q: %w", text, err)
+		}
+	}
+
+	*c = Condition{
+		Metric: fields[0],
+		Op:     fields[1],
+		Value:  value,
+	}
+	return nil
+}
+
+// eval evaluates the condition. Conditions on missing metrics are false.
+func (c Condition) eval(metrics map[string]float64) bool {
+	v, ok := metrics[c.Metric]
+	if !ok {
+		return false
+	}
+
+	switch c.Op {
+	case "<":
+		return v < c.Value
+	case "<=":
+		return v <= c.Value
+	case ">":
+		return v > c.Value
+	case ">=":
+		return v >= c.Value
+	case "==":
+		return v == c.Value
+	case "!=":
+		return v != c.Value
+	default:
+		return false
+	}
+}
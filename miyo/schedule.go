@@ -0,0 +1,88 @@
+package miyo
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Window is a time window within a day, e.g. "06:30-09:00".
+// Start and End are offsets from midnight.
+type Window struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+func (w Window) String() string {
+	format := func(d time.Duration) string {
+		return fmt.Sprintf("%02d:%02d", int(d.Hours()), int(d.Minutes())%60)
+	}
+	return format(w.Start) + "-" + format(w.End)
+}
+
+// Contains reports whether the time of day of t is within the window.
+func (w Window) Contains(t time.Time) bool {
+	y, m, d := t.Date()
+	offset := t.Sub(time.Date(y, m, d, 0, 0, 0, 0, t.Location()))
+	return offset >= w.Start && offset < w.End
+}
+
+// ParseWindows parses a list of windows as used by CircuitParams.Day0, e.g. "06:30-09:00;19:00-22:00".
+// An empty string results in an empty list.
+func ParseWindows(s string) ([]Window, error) {
+	var ret []Window
+	for _, field := range strings.Split(s, ";") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		parts := strings.Split(field, "-")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid window %q", field)
+		}
+
+		var w Window
+		for i, ptr := range []*time.Duration{&w.Start, &w.End} {
+			t, err := time.Parse("15:04", strings.TrimSpace(parts[i]))
+			if err != nil {
+				return nil, fmt.Errorf("invalid window %q: %w", field, err)
+			}
+			*ptr = time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+		}
+		if w.End == 0 {
+			// "22:00-00:00" ends at midnight.
+			w.End = 24 * time.Hour
+		}
+		if w.End <= w.Start {
+			return nil, fmt.Errorf("invalid window %q: end is not after start", field)
+		}
+
+		ret = append(ret, w)
+	}
+
+	return ret, nil
+}
+
+// Windows returns the irrigation windows of the given weekday.
+// Day0 is Monday and Day6 is Sunday.
+func (p CircuitParams) Windows(day time.Weekday) ([]Window, error) {
+	// time.Weekday starts with Sunday == 0.
+	idx := (int(day) + 6) % 7
+	return ParseWindows(p.Schedule()[idx])
+}
+
+// InSchedule reports whether t is within one of the irrigation windows of its weekday.
+func (p CircuitParams) InSchedule(t time.Time) (bool, error) {
+	windows, err := p.Windows(t.Weekday())
+	if err != nil {
+		return false, err
+	}
+
+	for _, w := range windows {
+		if w.Contains(t) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,62 @@
+package miyo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseWindows(t *testing.T) {
+	cases := []struct {
+		in      string
+		want    []Window
+		wantErr bool
+	}{
+		{"", nil, false},
+		{"06:30-09:00;19:00-22:00", []Window{{6*time.Hour + 30*time.Minute, 9 * time.Hour}, {19 * time.Hour, 22 * time.Hour}}, false},
+		{"22:00-00:00", []Window{{22 * time.Hour, 24 * time.Hour}}, false},
+		{"09:00-06:30", nil, true},
+		{"09:00", nil, true},
+		{"25:00-26:00", nil, true},
+	}
+
+	for _, tc := range cases {
+		got, err := ParseWindows(tc.in)
+		if gotErr := err != nil; gotErr != tc.wantErr {
+			t.Errorf("ParseWindows(%q) = %v, want error %v", tc.in, err, tc.wantErr)
+			continue
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("ParseWindows(%q) differs (-want/+got):\n%s", tc.in, diff)
+		}
+	}
+}
+
+func TestInSchedule(t *testing.T) {
+	p := CircuitParams{
+		Day0: "06:30-09:00",
+		Day6: "20:00-22:00",
+	}
+
+	cases := []struct {
+		t    time.Time
+		want bool
+	}{
+		// 2022-04-04 is a Monday.
+		{time.Date(2022, 4, 4, 7, 0, 0, 0, time.UTC), true},
+		{time.Date(2022, 4, 4, 9, 0, 0, 0, time.UTC), false},
+		{time.Date(2022, 4, 5, 7, 0, 0, 0, time.UTC), false},
+		{time.Date(2022, 4, 10, 21, 0, 0, 0, time.UTC), true},
+	}
+
+	for _, tc := range cases {
+		got, err := p.InSchedule(tc.t)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tc.want {
+			t.Errorf("InSchedule(%v) = %v, want %v", tc.t, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package script
+
+import (
+	"fmt"
+
+	"github.com/octo/miyo-go/miyo"
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+)
+
+// Action is a change of the MIYO Cube's state requested by a script.
+type Action struct {
+	Script string
+	// Func is the builtin that recorded the action, i.e. "irrigate" or "set_valve".
+	Func string
+	// ID is the ID of the circuit or device; Name is the circuit's name or the device's reference.
+	ID   string
+	Name string
+	// Device is the valve of a "set_valve" action.
+	Device miyo.DeviceRef
+	On     bool
+	Err    error
+}
+
+func (a Action) String() string {
+	s := fmt.Sprintf("%s %s %v (script %q)", a.Func, a.Name, a.On, a.Script)
+	if a.Err != nil {
+		s += fmt.Sprintf(": %v", a.Err)
+	}
+	return s
+}
+
+const recorderKey = "miyo.recorder"
+
+// recorder collects the actions of one call.
+type recorder struct {
+	script  string
+	snap    miyo.Snapshot
+	max     int
+	actions []Action
+}
+
+func (r *recorder) add(a Action) error {
+	if r.max > 0 && len(r.actions) >= r.max {
+		return fmt.Errorf("too many actions (limit %d)", r.max)
+	}
+	a.Script = r.script
+	r.actions = append(r.actions, a)
+	return nil
+}
+
+var builtins = starlark.StringDict{
+	"irrigate":  starlark.NewBuiltin("irrigate", irrigate),
+	"set_valve": starlark.NewBuiltin("set_valve", setValve),
+	"struct":    starlark.NewBuiltin("struct", starlarkstruct.Make),
+}
+
+func threadRecorder(thread *starlark.Thread, b *starlark.Builtin) (*recorder, error) {
+	rec, ok := thread.Local(recorderKey).(*recorder)
+	if !ok {
+		return nil, fmt.Errorf("%s: may only be called from on_poll", b.Name())
+	}
+	return rec, nil
+}
+
+// irrigate(area, on) starts or stops irrigating an area. area is an area of the snapshot, its ID or its name.
+func irrigate(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var (
+		area starlark.Value
+		on   bool
+	)
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "area", &area, "on", &on); err != nil {
+		return nil, err
+	}
+
+	rec, err := threadRecorder(thread, b)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := idOf(area)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", b.Name(), err)
+	}
+	c, ok := rec.snap.Areas.Find(key)
+	if !ok {
+		return nil, fmt.Errorf("%s: no such area: %q", b.Name(), key)
+	}
+	return starlark.None, rec.add(Action{Func: b.Name(), ID: c.ID, Name: c.Name, On: on})
+}
+
+// set_valve(device, open) opens or closes a valve. device is a device of the snapshot or its reference, i.e.
+// "{uuid};channel". The channel may be omitted if the unit has only one.
+func setValve(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var (
+		device starlark.Value
+		open   bool
+	)
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "device", &device, "open", &open); err != nil {
+		return nil, err
+	}
+
+	rec, err := threadRecorder(thread, b)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := refOf(device)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", b.Name(), err)
+	}
+	ref, err := miyo.ParseDeviceRef(id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", b.Name(), err)
+	}
+	var matches miyo.DeviceList
+	for _, d := range rec.snap.Devices {
+		if ref.Matches(d.Reference()) {
+			matches = append(matches, d)
+		}
+	}
+	switch {
+	case len(matches) == 0:
+		return nil, fmt.Errorf("%s: no such device: %q", b.Name(), id)
+	case len(matches) > 1:
+		return nil, fmt.Errorf("%s: device %q has %d channels, give one as \"{uuid};channel\"", b.Name(), id, len(matches))
+	}
+	d := matches[0]
+	if d.DeviceType() != miyo.DeviceType_Valve {
+		return nil, fmt.Errorf("%s: device %q is not a valve", b.Name(), id)
+	}
+	ref = d.Reference()
+	return starlark.None, rec.add(Action{Func: b.Name(), ID: d.ID, Name: ref.String(), Device: ref, On: open})
+}
+
+// refOf returns the "ref" field of a device view, or the string itself.
+func refOf(v starlark.Value) (string, error) {
+	if s, ok := v.(*starlarkstruct.Struct); ok {
+		if ref, err := s.Attr("ref"); err == nil {
+			if r, ok := ref.(starlark.String); ok {
+				return string(r), nil
+			}
+		}
+	}
+	return idOf(v)
+}
+
+// idOf returns the "id" field of an area or device view, or the string itself.
+func idOf(v starlark.Value) (string, error) {
+	switch v := v.(type) {
+	case starlark.String:
+		return string(v), nil
+	case *starlarkstruct.Struct:
+		id, err := v.Attr("id")
+		if err != nil {
+			return "", err
+		}
+		if s, ok := id.(starlark.String); ok {
+			return string(s), nil
+		}
+	}
+	return "", fmt.Errorf("want area, device or string, got %s", v.Type())
+}
@@ -0,0 +1,76 @@
+package script
+
+import (
+	"context"
+	"log"
+
+	"github.com/octo/miyo-go/miyo"
+)
+
+// Controller is the subset of *miyo.Conn used by the runner.
+type Controller interface {
+	SetIrrigation(ctx context.Context, circuitID string, irrigate bool) error
+	SetValve(ctx context.Context, ref miyo.DeviceRef, open bool) error
+}
+
+// Runner calls scripts with every snapshot and executes the recorded actions.
+type Runner struct {
+	Scripts    []*Script
+	Controller Controller
+	// Limits restricts every call of a script. The zero value means DefaultLimits.
+	Limits Limits
+	// DryRun reports actions without executing them.
+	DryRun bool
+}
+
+// Update runs all scripts and logs the resulting actions and errors.
+// Its signature allows it to be used as callback for miyo.Conn.Poll.
+func (r *Runner) Update(snap miyo.Snapshot) error {
+	actions, errs := r.Evaluate(context.Background(), snap)
+	for _, err := range errs {
+		log.Print(err)
+	}
+	for _, a := range actions {
+		log.Print(a)
+	}
+	return nil
+}
+
+// Evaluate runs all scripts in order and executes their actions. A failing script doesn't prevent the
+// remaining scripts from running; its error is returned and none of its actions are executed.
+func (r *Runner) Evaluate(ctx context.Context, snap miyo.Snapshot) ([]Action, []error) {
+	limits := r.Limits
+	if limits == (Limits{}) {
+		limits = DefaultLimits
+	}
+
+	var (
+		actions []Action
+		errs    []error
+	)
+	for _, s := range r.Scripts {
+		as, err := s.Run(snap, limits)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		for _, a := range as {
+			if !r.DryRun {
+				a.Err = r.execute(ctx, a)
+			}
+			actions = append(actions, a)
+		}
+	}
+
+	return actions, errs
+}
+
+func (r *Runner) execute(ctx context.Context, a Action) error {
+	switch a.Func {
+	case "set_valve":
+		return r.Controller.SetValve(ctx, a.Device, a.On)
+	default:
+		return r.Controller.SetIrrigation(ctx, a.ID, a.On)
+	}
+}
@@ -0,0 +1,122 @@
+// Package script runs user provided Starlark scripts on snapshots of the MIYO Cube.
+//
+// A script defines an on_poll function that is called with every snapshot:
+//
+//	def on_poll(snap):
+//	    for area in snap.areas:
+//	        if area.sensor.reachable and area.sensor.moisture < 30 and not area.irrigation:
+//	            irrigate(area, True)
+//
+// Scripts are sandboxed: they can't load modules or access files and the network, and the snapshot is a
+// read-only view. The only way to change the state of the MIYO Cube are the builtins irrigate(area, on) and
+// set_valve(device, open), which record actions that are executed after on_poll returns successfully.
+// Every call is limited in time and number of execution steps.
+package script
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/octo/miyo-go/miyo"
+	"go.starlark.net/starlark"
+)
+
+// Limits restrict the resources a script may use on each call.
+type Limits struct {
+	// Timeout is the maximum wall time of a call.
+	Timeout time.Duration
+	// MaxSteps is the maximum number of Starlark execution steps of a call.
+	MaxSteps uint64
+	// MaxActions is the maximum number of actions a call may record.
+	MaxActions int
+}
+
+// DefaultLimits are used by Load and by Runner if no limits are set.
+var DefaultLimits = Limits{
+	Timeout:    time.Second,
+	MaxSteps:   1000000,
+	MaxActions: 10,
+}
+
+// Script is a loaded Starlark script.
+type Script struct {
+	Name string
+
+	onPoll starlark.Callable
+}
+
+// Load executes the top level statements of a script and looks up its on_poll function.
+func Load(name string, src []byte) (*Script, error) {
+	thread, stop := newThread(name, DefaultLimits)
+	defer stop()
+
+	globals, err := starlark.ExecFile(thread, name, src, builtins)
+	if err != nil {
+		return nil, scriptError(name, err)
+	}
+
+	fn, ok := globals["on_poll"].(starlark.Callable)
+	if !ok {
+		return nil, fmt.Errorf("%s: missing function on_poll(snap)", name)
+	}
+
+	return &Script{
+		Name:   name,
+		onPoll: fn,
+	}, nil
+}
+
+// Run calls the script's on_poll function with a read-only view of snap and returns the recorded actions.
+// It doesn't execute the actions. If the script fails, no actions are returned.
+func (s *Script) Run(snap miyo.Snapshot, limits Limits) ([]Action, error) {
+	thread, stop := newThread(s.Name, limits)
+	defer stop()
+
+	rec := &recorder{
+		script: s.Name,
+		snap:   snap,
+		max:    limits.MaxActions,
+	}
+	thread.SetLocal(recorderKey, rec)
+
+	if _, err := starlark.Call(thread, s.onPoll, starlark.Tuple{snapshotValue(snap)}, nil); err != nil {
+		return nil, scriptError(s.Name, err)
+	}
+
+	return rec.actions, nil
+}
+
+// newThread returns a thread enforcing limits. The returned function releases the timer and has to be called
+// when execution is finished.
+func newThread(name string, limits Limits) (*starlark.Thread, func()) {
+	thread := &starlark.Thread{
+		Name: name,
+		Print: func(_ *starlark.Thread, msg string) {
+			log.Printf("%s: %s", name, msg)
+		},
+		// Load is nil, so load() statements fail.
+	}
+	if limits.MaxSteps > 0 {
+		thread.SetMaxExecutionSteps(limits.MaxSteps)
+	}
+
+	stop := func() {}
+	if limits.Timeout > 0 {
+		timer := time.AfterFunc(limits.Timeout, func() {
+			thread.Cancel(fmt.Sprintf("timeout after %v", limits.Timeout))
+		})
+		stop = func() { timer.Stop() }
+	}
+
+	return thread, stop
+}
+
+func scriptError(name string, err error) error {
+	var evalErr *starlark.EvalError
+	if errors.As(err, &evalErr) {
+		return fmt.Errorf("%s: %s", name, evalErr.Backtrace())
+	}
+	return fmt.Errorf("%s: %w", name, err)
+}
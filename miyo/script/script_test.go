@@ -0,0 +1,182 @@
+package script
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/octo/miyo-go/miyo"
+)
+
+type fakeController struct {
+	calls []string
+}
+
+func (c *fakeController) SetIrrigation(_ context.Context, id string, irrigate bool) error {
+	c.calls = append(c.calls, fmt.Sprintf("irrigation %s %v", id, irrigate))
+	return nil
+}
+
+func (c *fakeController) SetValve(_ context.Context, ref miyo.DeviceRef, open bool) error {
+	c.calls = append(c.calls, fmt.Sprintf("valve %s %v", ref, open))
+	return nil
+}
+
+var testSnapshot = miyo.Snapshot{
+	Time: time.Date(2022, 4, 4, 10, 0, 0, 0, time.UTC),
+	Areas: []miyo.Circuit{
+		{
+			ID:     "{b85746b6}",
+			Name:   "Rosen",
+			Params: miyo.CircuitParams{Day0: "06:00-22:00"},
+			SensorData: miyo.Device{
+				State: miyo.DeviceState{Reachable: true, Moisture: 30},
+			},
+		},
+		{
+			ID:   "{0d0b7ac4}",
+			Name: "Rasen",
+			SensorData: miyo.Device{
+				State: miyo.DeviceState{Reachable: true, Moisture: 60},
+			},
+		},
+	},
+	Devices: []miyo.Device{
+		{ID: "{7a3a6d66}", Type: "valve"},
+		{ID: "{e9e3b0c2}", Type: "moistureOutdoor"},
+		{ID: "{c0d7a6b1}", Type: "valve", Ref: miyo.DeviceRef{ID: "{c0d7a6b1}", Channel: 1}},
+		{ID: "{c0d7a6b1}", Type: "valve", Ref: miyo.DeviceRef{ID: "{c0d7a6b1}", Channel: 2}},
+	},
+}
+
+const testScript = `
+def on_poll(snap):
+    for area in snap.areas:
+        if area.in_schedule and area.sensor.moisture < 35:
+            irrigate(area, True)
+    set_valve("{7a3a6d66}", open = False)
+    set_valve(snap.devices[3], True)
+`
+
+func TestRunner(t *testing.T) {
+	s, err := Load("test.star", []byte(testScript))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctrl := &fakeController{}
+	r := &Runner{
+		Scripts:    []*Script{s},
+		Controller: ctrl,
+	}
+
+	actions, errs := r.Evaluate(context.Background(), testSnapshot)
+	if len(errs) != 0 {
+		t.Fatalf("Evaluate() = %v", errs)
+	}
+
+	var got []string
+	for _, a := range actions {
+		got = append(got, a.String())
+	}
+	want := []string{
+		`irrigate Rosen true (script "test.star")`,
+		`set_valve {7a3a6d66} false (script "test.star")`,
+		`set_valve {c0d7a6b1};2 true (script "test.star")`,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("actions differ (-want/+got):\n%s", diff)
+	}
+
+	wantCalls := []string{
+		"irrigation {b85746b6} true",
+		"valve {7a3a6d66} false",
+		"valve {c0d7a6b1};2 true",
+	}
+	if diff := cmp.Diff(wantCalls, ctrl.calls); diff != "" {
+		t.Errorf("calls differ (-want/+got):\n%s", diff)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	cases := []struct {
+		name    string
+		src     string
+		limits  Limits
+		wantErr string
+	}{
+		{
+			name:    "read-only snapshot",
+			src:     "def on_poll(snap):\n    snap.areas[0].name = 'x'\n",
+			wantErr: "can't assign",
+		},
+		{
+			name:    "unknown area",
+			src:     "def on_poll(snap):\n    irrigate('Tomaten', True)\n",
+			wantErr: "no such area",
+		},
+		{
+			name:    "not a valve",
+			src:     "def on_poll(snap):\n    set_valve(snap.devices[1], True)\n",
+			wantErr: "not a valve",
+		},
+		{
+			name:    "ambiguous channel",
+			src:     "def on_poll(snap):\n    set_valve('{c0d7a6b1}', True)\n",
+			wantErr: "has 2 channels",
+		},
+		{
+			name:    "too many actions",
+			src:     "def on_poll(snap):\n    for i in range(3):\n        irrigate('Rosen', True)\n",
+			limits:  Limits{MaxActions: 2},
+			wantErr: "too many actions",
+		},
+		{
+			name:    "step limit",
+			src:     "def on_poll(snap):\n    for i in range(1 << 40):\n        pass\n",
+			limits:  Limits{MaxSteps: 1000},
+			wantErr: "too many steps",
+		},
+		{
+			name:    "timeout",
+			src:     "def on_poll(snap):\n    for i in range(1 << 40):\n        pass\n",
+			limits:  Limits{Timeout: 10 * time.Millisecond},
+			wantErr: "timeout",
+		},
+	}
+
+	for _, tc := range cases {
+		s, err := Load(tc.name, []byte(tc.src))
+		if err != nil {
+			t.Fatalf("%s: Load() = %v", tc.name, err)
+		}
+
+		actions, err := s.Run(testSnapshot, tc.limits)
+		if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+			t.Errorf("%s: Run() = %v, want error containing %q", tc.name, err, tc.wantErr)
+		}
+		if len(actions) != 0 {
+			t.Errorf("%s: Run() returned actions despite error: %v", tc.name, actions)
+		}
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	cases := []struct {
+		name string
+		src  string
+	}{
+		{"missing on_poll", "x = 1\n"},
+		{"load", "load('os.star', 'system')\ndef on_poll(snap):\n    pass\n"},
+		{"action at top level", "irrigate('Rosen', True)\ndef on_poll(snap):\n    pass\n"},
+	}
+
+	for _, tc := range cases {
+		if _, err := Load(tc.name, []byte(tc.src)); err == nil {
+			t.Errorf("%s: Load() succeeded, want error", tc.name)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package script
+
+import (
+	"github.com/octo/miyo-go/miyo"
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+)
+
+// snapshotValue returns a read-only view of snap with the fields time, areas and devices.
+func snapshotValue(snap miyo.Snapshot) starlark.Value {
+	var areas, devices []starlark.Value
+	for _, c := range snap.Areas {
+		areas = append(areas, circuitValue(c, snap))
+	}
+	for _, d := range snap.Devices {
+		devices = append(devices, deviceValue(d))
+	}
+
+	v := starlarkstruct.FromStringDict(starlark.String("snapshot"), starlark.StringDict{
+		"time":    starlark.MakeInt64(snap.Time.Unix()),
+		"areas":   starlark.Tuple(areas),
+		"devices": starlark.Tuple(devices),
+	})
+	v.Freeze()
+	return v
+}
+
+func circuitValue(c miyo.Circuit, snap miyo.Snapshot) starlark.Value {
+	inSchedule, _ := c.Params.InSchedule(snap.Time)
+
+	schedule := c.Params.Schedule()
+	var days []starlark.Value
+	for _, windows := range schedule {
+		days = append(days, starlark.String(windows))
+	}
+
+	// Scripts see the valves in a stable order. The channel in the valve's data isn't reliable, so the
+	// reference comes from the circuit.
+	var valves []starlark.Value
+	for _, v := range c.SortedValves() {
+		d := v.Data
+		d.Ref = v.Ref()
+		valves = append(valves, deviceValue(d))
+	}
+	sensor := c.SensorData
+	sensor.Ref = c.SensorRef()
+
+	return starlarkstruct.FromStringDict(starlark.String("area"), starlark.StringDict{
+		"id":             starlark.String(c.ID),
+		"name":           starlark.String(c.Name),
+		"status":         starlark.String(c.Status()),
+		"irrigation":     starlark.Bool(c.State.Irrigation),
+		"automatic_mode": starlark.Bool(c.State.AutomaticMode),
+		"winter_mode":    starlark.Bool(c.State.WinterMode),
+		"extern_block":   starlark.Bool(c.State.ExternBlock),
+		"border_bottom":  starlark.MakeInt(int(c.Params.BorderBottom)),
+		"border_top":     starlark.MakeInt(int(c.Params.BorderTop)),
+		"schedule":       starlark.Tuple(days),
+		"in_schedule":    starlark.Bool(inSchedule),
+		"sensor":         deviceValue(sensor),
+		"valves":         starlark.Tuple(valves),
+	})
+}
+
+func deviceValue(d miyo.Device) starlark.Value {
+	s := d.State
+	return starlarkstruct.FromStringDict(starlark.String("device"), starlark.StringDict{
+		"id":                   starlark.String(d.ID),
+		"ref":                  starlark.String(d.Reference().String()),
+		"type":                 starlark.String(d.Type),
+		"status":               starlark.String(d.Status()),
+		"last_update":          starlark.MakeInt(d.LastUpdate),
+		"reachable":            starlark.Bool(s.Reachable),
+		"moisture":             starlark.MakeInt(s.Moisture),
+		"temperature":          starlark.MakeInt(s.Temperature),
+		"brightness":           starlark.MakeInt(s.Brightness),
+		"rssi":                 starlark.MakeInt(s.RSSI),
+		"valve_open":           starlark.Bool(s.ValveStatus),
+		"low_power":            starlark.Bool(s.LowPower),
+		"charging":             starlark.Bool(s.Charging),
+		"solar_voltage":        starlark.MakeInt(s.SolarVoltage),
+		"irrigation_necessary": starlark.Bool(s.IrrigationNecessary),
+		"irrigation_possible":  starlark.Bool(s.IrrigationPossible),
+	})
+}
@@ -0,0 +1,481 @@
+// Package server provides a JSON REST API and a web dashboard for a MIYO Cube.
+//
+// The server caches the most recent state of the MIYO Cube, so that any number of clients can share one connection.
+package server
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/octo/miyo-go/miyo"
+	"github.com/octo/miyo-go/miyo/forecast"
+	"github.com/octo/miyo-go/miyo/history"
+)
+
+//go:embed static
+var static embed.FS
+
+// Cube is the subset of *miyo.Conn used by the server.
+type Cube interface {
+	Snapshot(ctx context.Context) (miyo.Snapshot, error)
+	SetIrrigation(ctx context.Context, circuitID string, irrigate bool) error
+	SetAutomaticMode(ctx context.Context, circuitID string, enabled bool) error
+	SetValve(ctx context.Context, ref miyo.DeviceRef, open bool) error
+	SetSchedule(ctx context.Context, circuitID string, day int, windows string) error
+}
+
+// Action is an operation a client may be allowed to perform on a circuit.
+type Action int
+
+const (
+	// Action_Read allows reading the state of a circuit and its devices.
+	Action_Read Action = iota
+	// Action_Irrigate allows starting and stopping irrigation and opening and closing valves.
+	Action_Irrigate
+	// Action_Configure allows changing the automatic mode and the schedule.
+	Action_Configure
+)
+
+func (a Action) String() string {
+	names := map[Action]string{
+		Action_Read:      "read",
+		Action_Irrigate:  "irrigate",
+		Action_Configure: "configure",
+	}
+	if name, ok := names[a]; ok {
+		return name
+	}
+	return fmt.Sprintf("Action#%d", a)
+}
+
+// Policy decides which actions the client that sent a request may perform.
+type Policy interface {
+	// Allow reports whether the client may perform action on circuit c.
+	// Devices that don't belong to any circuit are checked with the zero Circuit.
+	Allow(r *http.Request, action Action, c miyo.Circuit) bool
+}
+
+// Server serves the REST API and the web dashboard.
+type Server struct {
+	Cube Cube
+	// History, if not nil, is used to answer history queries.
+	History *history.Store
+	// Policy, if not nil, restricts what clients may do.
+	Policy Policy
+
+	mu   sync.Mutex
+	snap miyo.Snapshot
+}
+
+// Update replaces the cached state of the MIYO Cube.
+// Its signature allows it to be used as callback for miyo.Conn.Poll.
+func (s *Server) Update(snap miyo.Snapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.snap = snap
+	return nil
+}
+
+func (s *Server) snapshot() miyo.Snapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.snap
+}
+
+func (s *Server) allow(r *http.Request, action Action, c miyo.Circuit) bool {
+	if s.Policy == nil {
+		return true
+	}
+	return s.Policy.Allow(r, action, c)
+}
+
+// allowDevice reports whether action is allowed on any circuit the device belongs to.
+func (s *Server) allowDevice(r *http.Request, action Action, d miyo.Device, areas []miyo.Circuit) bool {
+	found := false
+	for _, a := range areas {
+		if !containsDevice(a, d.Reference()) {
+			continue
+		}
+		found = true
+		if s.allow(r, action, a) {
+			return true
+		}
+	}
+	return !found && s.allow(r, action, miyo.Circuit{})
+}
+
+func containsDevice(c miyo.Circuit, ref miyo.DeviceRef) bool {
+	if (c.Sensor != "" && c.SensorRef().Matches(ref)) || (c.SensorValve.Valve != "" && c.SensorValve.Ref().Matches(ref)) {
+		return true
+	}
+	for _, v := range c.Valves {
+		if v.Ref().Matches(ref) {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupDevice returns the device referred to by id, e.g. "{uuid};1". The channel may be omitted if the unit has
+// only one.
+func lookupDevice(devs miyo.DeviceList, id string) (miyo.Device, bool) {
+	ref, err := miyo.ParseDeviceRef(id)
+	if err != nil {
+		return miyo.Device{}, false
+	}
+	n := 0
+	for _, d := range devs {
+		if ref.Matches(d.Reference()) {
+			n++
+		}
+	}
+	if n != 1 {
+		return miyo.Device{}, false
+	}
+	return devs.Lookup(ref)
+}
+
+// refresh updates the cached state after a control operation.
+func (s *Server) refresh(ctx context.Context) {
+	snap, err := s.Cube.Snapshot(ctx)
+	if err != nil {
+		log.Printf("Snapshot: %v", err)
+		return
+	}
+	s.Update(snap)
+}
+
+// Handler returns the HTTP handler serving the API below "/api/" and the dashboard at "/".
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/areas", s.handleAreas)
+	mux.HandleFunc("/api/areas/", s.handleArea)
+	mux.HandleFunc("/api/devices", s.handleDevices)
+	mux.HandleFunc("/api/devices/", s.handleDevice)
+	mux.HandleFunc("/api/history", s.handleHistory)
+
+	root, err := fs.Sub(static, "static")
+	if err != nil {
+		panic(err)
+	}
+	mux.Handle("/", http.FileServer(http.FS(root)))
+
+	return mux
+}
+
+// httpError is an error with an associated HTTP status code.
+type httpError struct {
+	code int
+	err  error
+}
+
+func (e httpError) Error() string {
+	return e.err.Error()
+}
+
+func errorf(code int, format string, args ...interface{}) error {
+	return httpError{
+		code: code,
+		err:  fmt.Errorf(format, args...),
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}, err error) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if err != nil {
+		code := http.StatusInternalServerError
+		var herr httpError
+		if errors.As(err, &herr) {
+			code = herr.code
+		}
+		w.WriteHeader(code)
+		v = map[string]string{"error": err.Error()}
+	}
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("encoding response: %v", err)
+	}
+}
+
+func (s *Server) handleAreas(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeJSON(w, nil, errorf(http.StatusMethodNotAllowed, "method %s not allowed", r.Method))
+		return
+	}
+
+	areas := []miyo.Circuit{}
+	for _, a := range s.snapshot().Areas {
+		if s.allow(r, Action_Read, a) {
+			areas = append(areas, a)
+		}
+	}
+	writeJSON(w, areas, nil)
+}
+
+// handleArea serves "/api/areas/<id>", "/api/areas/<id>/forecast" and the control endpoints
+// "/api/areas/<id>/irrigation", "/api/areas/<id>/automatic-mode" and "/api/areas/<id>/schedule".
+func (s *Server) handleArea(w http.ResponseWriter, r *http.Request) {
+	id, action := splitPath(strings.TrimPrefix(r.URL.Path, "/api/areas/"))
+
+	var area *miyo.Circuit
+	for _, a := range s.snapshot().Areas {
+		if a.ID == id {
+			a := a
+			area = &a
+			break
+		}
+	}
+	if area == nil || !s.allow(r, Action_Read, *area) {
+		writeJSON(w, nil, errorf(http.StatusNotFound, "area %q not found", id))
+		return
+	}
+
+	required := map[string]Action{
+		"":               Action_Read,
+		"irrigation":     Action_Irrigate,
+		"automatic-mode": Action_Configure,
+		"schedule":       Action_Configure,
+		"forecast":       Action_Read,
+	}
+	if a, ok := required[action]; ok && !s.allow(r, a, *area) {
+		writeJSON(w, nil, errorf(http.StatusForbidden, "%s not allowed on area %q", a, area.Name))
+		return
+	}
+
+	switch {
+	case action == "" && r.Method == http.MethodGet:
+		writeJSON(w, area, nil)
+	case action == "irrigation" && r.Method == http.MethodPost:
+		s.handleSwitch(w, r, func(ctx context.Context, on bool) error {
+			return s.Cube.SetIrrigation(ctx, id, on)
+		})
+	case action == "automatic-mode" && r.Method == http.MethodPost:
+		s.handleSwitch(w, r, func(ctx context.Context, on bool) error {
+			return s.Cube.SetAutomaticMode(ctx, id, on)
+		})
+	case action == "schedule" && r.Method == http.MethodPost:
+		s.handleSchedule(w, r, id)
+	case action == "forecast" && r.Method == http.MethodGet:
+		s.handleForecast(w, *area)
+	default:
+		writeJSON(w, nil, errorf(http.StatusNotFound, "%s %s not found", r.Method, r.URL.Path))
+	}
+}
+
+func (s *Server) handleDevices(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeJSON(w, nil, errorf(http.StatusMethodNotAllowed, "method %s not allowed", r.Method))
+		return
+	}
+
+	snap := s.snapshot()
+	devs := []miyo.Device{}
+	for _, d := range snap.Devices {
+		if s.allowDevice(r, Action_Read, d, snap.Areas) {
+			devs = append(devs, d)
+		}
+	}
+	writeJSON(w, devs, nil)
+}
+
+// handleDevice serves "/api/devices/<id>" and the control endpoint "/api/devices/<id>/valve".
+func (s *Server) handleDevice(w http.ResponseWriter, r *http.Request) {
+	id, action := splitPath(strings.TrimPrefix(r.URL.Path, "/api/devices/"))
+
+	snap := s.snapshot()
+	dev, ok := lookupDevice(snap.Devices, id)
+	if !ok || !s.allowDevice(r, Action_Read, dev, snap.Areas) {
+		writeJSON(w, nil, errorf(http.StatusNotFound, "device %q not found", id))
+		return
+	}
+	if action == "valve" && !s.allowDevice(r, Action_Irrigate, dev, snap.Areas) {
+		writeJSON(w, nil, errorf(http.StatusForbidden, "%s not allowed on device %q", Action_Irrigate, id))
+		return
+	}
+
+	switch {
+	case action == "" && r.Method == http.MethodGet:
+		writeJSON(w, dev, nil)
+	case action == "valve" && r.Method == http.MethodPost && dev.DeviceType() == miyo.DeviceType_Valve:
+		s.handleSwitch(w, r, func(ctx context.Context, on bool) error {
+			return s.Cube.SetValve(ctx, dev.Reference(), on)
+		})
+	default:
+		writeJSON(w, nil, errorf(http.StatusNotFound, "%s %s not found", r.Method, r.URL.Path))
+	}
+}
+
+// switchRequest is the body of all control requests.
+type switchRequest struct {
+	On bool `json:"on"`
+}
+
+func (s *Server) handleSwitch(w http.ResponseWriter, r *http.Request, set func(context.Context, bool) error) {
+	var req switchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, nil, errorf(http.StatusBadRequest, "parsing request: %v", err))
+		return
+	}
+
+	if err := set(r.Context(), req.On); err != nil {
+		writeJSON(w, nil, errorf(http.StatusBadGateway, "%v", err))
+		return
+	}
+
+	s.refresh(r.Context())
+	writeJSON(w, map[string]string{"status": "success"}, nil)
+}
+
+// scheduleRequest is the body of requests changing the schedule of an area.
+type scheduleRequest struct {
+	// Day is the index of the day, see miyo.Conn.SetSchedule.
+	Day     int    `json:"day"`
+	Windows string `json:"windows"`
+}
+
+func (s *Server) handleSchedule(w http.ResponseWriter, r *http.Request, id string) {
+	var req scheduleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, nil, errorf(http.StatusBadRequest, "parsing request: %v", err))
+		return
+	}
+
+	if err := s.Cube.SetSchedule(r.Context(), id, req.Day, req.Windows); err != nil {
+		writeJSON(w, nil, errorf(http.StatusBadGateway, "%v", err))
+		return
+	}
+
+	s.refresh(r.Context())
+	writeJSON(w, map[string]string{"status": "success"}, nil)
+}
+
+// handleForecast serves "/api/areas/<id>/forecast".
+func (s *Server) handleForecast(w http.ResponseWriter, area miyo.Circuit) {
+	if s.History == nil {
+		writeJSON(w, nil, errorf(http.StatusNotFound, "history is not enabled"))
+		return
+	}
+
+	// The snapshot's time is in the MIYO Cube's time zone, see miyo.WithLocation.
+	p, err := forecast.Predictor{History: s.History}.Predict(area, s.snapshot().Time)
+	if err != nil {
+		writeJSON(w, nil, err)
+		return
+	}
+
+	writeJSON(w, struct {
+		Moisture  float64 `json:"moisture"`
+		Threshold float64 `json:"threshold"`
+		Rate      float64 `json:"rate"`
+		Fitted    bool    `json:"fitted"`
+		At        int64   `json:"at"`
+		Seconds   int64   `json:"seconds"`
+		Message   string  `json:"message"`
+	}{p.Moisture, p.Threshold, p.Rate, p.Fitted, p.At.Unix(), int64(p.In.Seconds()), p.String()}, nil)
+}
+
+// handleHistory serves "/api/history?id=<id>&metric=<metric>[&since=<duration>][&resolution=<duration>]".
+func (s *Server) handleHistory(w http.ResponseWriter, r *http.Request) {
+	if s.History == nil {
+		writeJSON(w, nil, errorf(http.StatusNotFound, "history is not enabled"))
+		return
+	}
+
+	q := r.URL.Query()
+	key, ok := s.historyKey(q.Get("id"))
+	if !ok || !s.allowID(r, Action_Read, q.Get("id")) {
+		writeJSON(w, nil, errorf(http.StatusNotFound, "%q not found", q.Get("id")))
+		return
+	}
+
+	since, err := parseDuration(q.Get("since"), 7*24*time.Hour)
+	if err != nil {
+		writeJSON(w, nil, errorf(http.StatusBadRequest, "since: %v", err))
+		return
+	}
+	res, err := parseDuration(q.Get("resolution"), time.Hour)
+	if err != nil {
+		writeJSON(w, nil, errorf(http.StatusBadRequest, "resolution: %v", err))
+		return
+	}
+
+	now := s.snapshot().Time
+	samples, err := s.History.Query(history.Query{
+		ID:         key,
+		Metric:     q.Get("metric"),
+		From:       now.Add(-since),
+		To:         now,
+		Resolution: res,
+	})
+	if err != nil {
+		writeJSON(w, nil, err)
+		return
+	}
+
+	type point struct {
+		Time  int64   `json:"t"`
+		Value float64 `json:"v"`
+	}
+	points := make([]point, 0, len(samples))
+	for _, smpl := range samples {
+		points = append(points, point{smpl.Time.Unix(), smpl.Value})
+	}
+	writeJSON(w, points, nil)
+}
+
+// allowID reports whether action is allowed on the circuit or device with the given ID.
+func (s *Server) allowID(r *http.Request, action Action, id string) bool {
+	snap := s.snapshot()
+	for _, a := range snap.Areas {
+		if a.ID == id {
+			return s.allow(r, action, a)
+		}
+	}
+	if d, ok := lookupDevice(snap.Devices, id); ok {
+		return s.allowDevice(r, action, d, snap.Areas)
+	}
+	return s.allow(r, action, miyo.Circuit{})
+}
+
+// historyKey returns the key of id's samples in the history store: the ID of an area or the reference of a device,
+// e.g. "{uuid};1". A device ID without channel is accepted if the unit has only one channel.
+func (s *Server) historyKey(id string) (string, bool) {
+	snap := s.snapshot()
+	for _, a := range snap.Areas {
+		if a.ID == id {
+			return id, true
+		}
+	}
+	d, ok := lookupDevice(snap.Devices, id)
+	if !ok {
+		return "", false
+	}
+	return d.Reference().String(), true
+}
+
+func parseDuration(s string, def time.Duration) (time.Duration, error) {
+	if s == "" {
+		return def, nil
+	}
+	return time.ParseDuration(s)
+}
+
+// splitPath splits "<id>/<action>" into its components. action is empty if p contains no slash.
+func splitPath(p string) (id, action string) {
+	if i := strings.Index(p, "/"); i >= 0 {
+		return p[:i], p[i+1:]
+	}
+	return p, ""
+}
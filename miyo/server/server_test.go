@@ -0,0 +1,157 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/octo/miyo-go/miyo"
+)
+
+type fakeCube struct {
+	snap  miyo.Snapshot
+	calls []string
+}
+
+func (c *fakeCube) Snapshot(_ context.Context) (miyo.Snapshot, error) {
+	return c.snap, nil
+}
+
+func (c *fakeCube) SetIrrigation(_ context.Context, id string, on bool) error {
+	c.calls = append(c.calls, "irrigation "+id+" "+onOff(on))
+	return nil
+}
+
+func (c *fakeCube) SetAutomaticMode(_ context.Context, id string, on bool) error {
+	c.calls = append(c.calls, "automaticMode "+id+" "+onOff(on))
+	return nil
+}
+
+func (c *fakeCube) SetValve(_ context.Context, ref miyo.DeviceRef, on bool) error {
+	c.calls = append(c.calls, "valve "+ref.String()+" "+onOff(on))
+	return nil
+}
+
+func (c *fakeCube) SetSchedule(_ context.Context, id string, day int, windows string) error {
+	c.calls = append(c.calls, fmt.Sprintf("schedule %s %d %s", id, day, windows))
+	return nil
+}
+
+func onOff(b bool) string {
+	if b {
+		return "on"
+	}
+	return "off"
+}
+
+func TestServer(t *testing.T) {
+	cube := &fakeCube{
+		snap: miyo.Snapshot{
+			Areas: []miyo.Circuit{
+				{ID: "{b85746b6}", Name: "Rosen"},
+			},
+			Devices: []miyo.Device{
+				{ID: "{e00cc4d9}", Type: "valve"},
+				{ID: "{a6563d0a}", Type: "moistureOutdoor"},
+				{ID: "{f223afe9}", Channel: 1, Type: "valve"},
+				{ID: "{f223afe9}", Channel: 2, Type: "valve"},
+			},
+		},
+	}
+	srv := &Server{Cube: cube}
+	srv.Update(cube.snap)
+
+	cases := []struct {
+		method, path, body string
+		wantCode           int
+		wantName           string
+	}{
+		{"GET", "/api/areas/{b85746b6}", "", http.StatusOK, "Rosen"},
+		{"GET", "/api/areas/{unknown}", "", http.StatusNotFound, ""},
+		{"POST", "/api/areas/{b85746b6}/irrigation", `{"on":true}`, http.StatusOK, ""},
+		{"POST", "/api/areas/{b85746b6}/automatic-mode", `{"on":false}`, http.StatusOK, ""},
+		{"POST", "/api/areas/{b85746b6}/irrigation", `not json`, http.StatusBadRequest, ""},
+		{"POST", "/api/areas/{b85746b6}/schedule", `{"day":2,"windows":"06:00-07:00"}`, http.StatusOK, ""},
+		{"POST", "/api/devices/{e00cc4d9}/valve", `{"on":true}`, http.StatusOK, ""},
+		{"POST", "/api/devices/{a6563d0a}/valve", `{"on":true}`, http.StatusNotFound, ""},
+		{"POST", "/api/devices/{f223afe9};2/valve", `{"on":false}`, http.StatusOK, ""},
+		// Ambiguous: the unit has two channels.
+		{"GET", "/api/devices/{f223afe9}", "", http.StatusNotFound, ""},
+		{"GET", "/api/history?id={a6563d0a}&metric=moisture", "", http.StatusNotFound, ""},
+	}
+
+	h := srv.Handler()
+	for _, tc := range cases {
+		req := httptest.NewRequest(tc.method, "http://miyo"+(&url.URL{Path: strings.Split(tc.path, "?")[0]}).EscapedPath(), strings.NewReader(tc.body))
+		if i := strings.Index(tc.path, "?"); i >= 0 {
+			req.URL.RawQuery = tc.path[i+1:]
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		if rec.Code != tc.wantCode {
+			t.Errorf("%s %s: got status %d, want %d (%s)", tc.method, tc.path, rec.Code, tc.wantCode, rec.Body)
+			continue
+		}
+		if tc.wantName == "" {
+			continue
+		}
+
+		var got struct {
+			Name string `json:"name"`
+		}
+		if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		if got.Name != tc.wantName {
+			t.Errorf("%s %s: got name %q, want %q", tc.method, tc.path, got.Name, tc.wantName)
+		}
+	}
+
+	wantCalls := []string{
+		"irrigation {b85746b6} on",
+		"automaticMode {b85746b6} off",
+		"schedule {b85746b6} 2 06:00-07:00",
+		"valve {e00cc4d9} on",
+		"valve {f223afe9};2 off",
+	}
+	if diff := cmp.Diff(wantCalls, cube.calls); diff != "" {
+		t.Errorf("calls differ (-want/+got):\n%s", diff)
+	}
+}
+
+func TestHistoryKey(t *testing.T) {
+	srv := &Server{}
+	srv.Update(miyo.Snapshot{
+		Areas: []miyo.Circuit{{ID: "{b85746b6}"}},
+		Devices: []miyo.Device{
+			{ID: "{f223afe9}", Channel: 1, Type: "valve"},
+			{ID: "{f223afe9}", Channel: 2, Type: "valve"},
+			{ID: "{a6563d0a}", Channel: 1, Type: "moistureOutdoor"},
+		},
+	})
+
+	cases := []struct {
+		id, want string
+		ok       bool
+	}{
+		{"{b85746b6}", "{b85746b6}", true},
+		{"{f223afe9};2", "{f223afe9};2", true},
+		{"{a6563d0a}", "{a6563d0a};1", true},
+		// Ambiguous: the unit has two channels.
+		{"{f223afe9}", "", false},
+		{"{unknown}", "", false},
+	}
+	for _, tc := range cases {
+		got, ok := srv.historyKey(tc.id)
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("historyKey(%q) = (%q, %v), want (%q, %v)", tc.id, got, ok, tc.want, tc.ok)
+		}
+	}
+}
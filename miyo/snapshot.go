@@ -0,0 +1,65 @@
+package miyo
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// Snapshot holds the state of all areas and devices at one point in time.
+type Snapshot struct {
+	// Time is in the MIYO Cube's time zone, see WithLocation.
+	Time    time.Time
+	Areas   AreaList
+	Devices DeviceList
+}
+
+// Location returns the time zone of the MIYO Cube the snapshot was taken of.
+func (s Snapshot) Location() *time.Location {
+	return s.Time.Location()
+}
+
+// Snapshot queries all areas and devices from the MIYO Cube.
+func (c *Conn) Snapshot(ctx context.Context) (Snapshot, error) {
+	now := time.Now().In(c.Location())
+
+	areas, err := c.Areas(ctx)
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("Areas: %w", err)
+	}
+
+	devs, err := c.Devices(ctx)
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("Devices: %w", err)
+	}
+
+	return Snapshot{
+		Time:    now,
+		Areas:   areas,
+		Devices: devs,
+	}, nil
+}
+
+// Poll calls fn with a new Snapshot immediately and then once every interval.
+// Errors querying the MIYO Cube are logged and the poll is retried at the next interval.
+// Poll returns when ctx is cancelled or fn returns an error.
+func (c *Conn) Poll(ctx context.Context, interval time.Duration, fn func(Snapshot) error) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		snap, err := c.Snapshot(ctx)
+		if err != nil {
+			log.Printf("Snapshot: %v", err)
+		} else if err := fn(snap); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,269 @@
+package miyo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// humanName translates the name of an enum value, e.g. "very_dry".
+func humanName(name string) string {
+	return tr(strings.ReplaceAll(name, "_", " "))
+}
+
+// Reachability reports whether a device, or an area's sensor, is accessible from the MIYO Cube.
+type Reachability int
+
+const (
+	Reachability_Unreachable Reachability = iota
+	Reachability_Reachable
+)
+
+var reachabilityNames = map[Reachability]string{
+	Reachability_Unreachable: "unreachable",
+	Reachability_Reachable:   "reachable",
+}
+
+func (r Reachability) String() string {
+	if name, ok := reachabilityNames[r]; ok {
+		return humanName(name)
+	}
+	return fmt.Sprintf("Reachability#%d", r)
+}
+
+// MarshalText implements encoding.TextMarshaler. The text is not translated.
+func (r Reachability) MarshalText() ([]byte, error) {
+	return marshalName(reachabilityNames[r], "Reachability", int(r))
+}
+
+// Dryness is the dryness of the soil as reported by a moisture sensor.
+type Dryness int
+
+const (
+	// Dryness_Unknown is used for devices that are no sensors and for unreachable sensors.
+	Dryness_Unknown Dryness = iota
+	Dryness_Moist
+	// Dryness_Dry corresponds to DeviceState.IrrigationPossible.
+	Dryness_Dry
+	// Dryness_VeryDry corresponds to DeviceState.IrrigationNecessary.
+	Dryness_VeryDry
+)
+
+var drynessNames = map[Dryness]string{
+	Dryness_Unknown: "unknown",
+	Dryness_Moist:   "moist",
+	Dryness_Dry:     "dry",
+	Dryness_VeryDry: "very_dry",
+}
+
+func (d Dryness) String() string {
+	if name, ok := drynessNames[d]; ok {
+		return humanName(name)
+	}
+	return fmt.Sprintf("Dryness#%d", d)
+}
+
+// MarshalText implements encoding.TextMarshaler. The text is not translated.
+func (d Dryness) MarshalText() ([]byte, error) {
+	return marshalName(drynessNames[d], "Dryness", int(d))
+}
+
+// IrrigationState is the irrigation state of an area or valve.
+type IrrigationState int
+
+const (
+	IrrigationState_Idle IrrigationState = iota
+	// IrrigationState_Starting means the valve is to be opened.
+	IrrigationState_Starting
+	IrrigationState_Active
+	// IrrigationState_Stopping means the valve is open but to be closed.
+	// Devices never report it: the Cube sets valveInitialClose on idle
+	// valves, too, so it does not tell a closing valve apart.
+	IrrigationState_Stopping
+)
+
+var irrigationStateNames = map[IrrigationState]string{
+	IrrigationState_Idle:     "idle",
+	IrrigationState_Starting: "starting",
+	IrrigationState_Active:   "active",
+	IrrigationState_Stopping: "stopping",
+}
+
+func (s IrrigationState) String() string {
+	if name, ok := irrigationStateNames[s]; ok {
+		return humanName(name)
+	}
+	return fmt.Sprintf("IrrigationState#%d", s)
+}
+
+// MarshalText implements encoding.TextMarshaler. The text is not translated.
+func (s IrrigationState) MarshalText() ([]byte, error) {
+	return marshalName(irrigationStateNames[s], "IrrigationState", int(s))
+}
+
+// Warning is a condition of a device that needs attention.
+type Warning int
+
+const (
+	// Warning_LowPower means the device's battery is low.
+	Warning_LowPower Warning = iota
+	// Warning_ChargingLess means the device's solar panel doesn't charge enough.
+	Warning_ChargingLess
+	// Warning_NoSun means the device had no sun within the last week.
+	Warning_NoSun
+	// Warning_OTAPending means a firmware update can be installed.
+	Warning_OTAPending
+)
+
+var warningNames = map[Warning]string{
+	Warning_LowPower:     "low_power",
+	Warning_ChargingLess: "charging_less",
+	Warning_NoSun:        "no_sun",
+	Warning_OTAPending:   "ota_pending",
+}
+
+func (w Warning) String() string {
+	if name, ok := warningNames[w]; ok {
+		return humanName(name)
+	}
+	return fmt.Sprintf("Warning#%d", w)
+}
+
+// MarshalText implements encoding.TextMarshaler. The text is not translated.
+func (w Warning) MarshalText() ([]byte, error) {
+	return marshalName(warningNames[w], "Warning", int(w))
+}
+
+func marshalName(name, typ string, value int) ([]byte, error) {
+	if name == "" {
+		return nil, fmt.Errorf("invalid %s %d", typ, value)
+	}
+	return []byte(name), nil
+}
+
+// Status is the structured form of Device.Status() and Circuit.Status().
+type Status struct {
+	Reachability Reachability `json:"reachability"`
+	Dryness      Dryness      `json:"dryness"`
+	// Moisture is the soil moisture in percent. It is only set if Dryness is not Dryness_Unknown.
+	Moisture   int             `json:"moisture"`
+	Irrigation IrrigationState `json:"irrigation"`
+	Warnings   []Warning       `json:"warnings,omitempty"`
+}
+
+// Reachable reports whether the device or the area's sensor is reachable.
+func (s Status) Reachable() bool {
+	return s.Reachability == Reachability_Reachable
+}
+
+// String formats the status, e.g. "moisture 40%; very dry; irrigation active; low power".
+// The text is translated into the language selected with SetLanguage.
+func (s Status) String() string {
+	var parts []string
+	if !s.Reachable() {
+		parts = append(parts, s.Reachability.String())
+	}
+	if s.Dryness != Dryness_Unknown {
+		parts = append(parts, fmt.Sprintf(tr("moisture %d%%"), s.Moisture))
+		if s.Dryness != Dryness_Moist {
+			parts = append(parts, s.Dryness.String())
+		}
+	}
+	if s.Irrigation != IrrigationState_Idle {
+		parts = append(parts, fmt.Sprintf(tr("irrigation %s"), s.Irrigation))
+	}
+	for _, w := range s.Warnings {
+		parts = append(parts, w.String())
+	}
+
+	if len(parts) == 0 {
+		return s.Reachability.String()
+	}
+	return strings.Join(parts, "; ")
+}
+
+// addWarnings adds ws to the status' warnings, keeping them ordered and free of duplicates.
+func (s *Status) addWarnings(ws ...Warning) {
+	seen := map[Warning]bool{}
+	for _, w := range s.Warnings {
+		seen[w] = true
+	}
+	for _, w := range ws {
+		if !seen[w] {
+			seen[w] = true
+			s.Warnings = append(s.Warnings, w)
+		}
+	}
+	sort.Slice(s.Warnings, func(i, j int) bool { return s.Warnings[i] < s.Warnings[j] })
+}
+
+// StructuredStatus returns the status of the device.
+func (d Device) StructuredStatus() Status {
+	var s Status
+	if d.State.Reachable {
+		s.Reachability = Reachability_Reachable
+	}
+
+	switch d.DeviceType() {
+	case DeviceType_Valve:
+		st := d.State
+		switch {
+		case st.ValveStatus:
+			s.Irrigation = IrrigationState_Active
+		case st.OpenValve:
+			s.Irrigation = IrrigationState_Starting
+		}
+	case DeviceType_MoistureSensor:
+		s.setMoisture(d.State)
+	}
+
+	if d.State.LowPower {
+		s.addWarnings(Warning_LowPower)
+	}
+	if d.State.ChargingLess {
+		s.addWarnings(Warning_ChargingLess)
+	}
+	if !d.State.SunWithinWeek {
+		s.addWarnings(Warning_NoSun)
+	}
+	if d.State.OTAUPossible {
+		s.addWarnings(Warning_OTAPending)
+	}
+
+	return s
+}
+
+// setMoisture sets the moisture and dryness reported by a reachable sensor.
+func (s *Status) setMoisture(st DeviceState) {
+	if !s.Reachable() {
+		return
+	}
+
+	s.Moisture = st.Moisture
+	switch {
+	case st.IrrigationNecessary:
+		s.Dryness = Dryness_VeryDry
+	case st.IrrigationPossible:
+		s.Dryness = Dryness_Dry
+	default:
+		s.Dryness = Dryness_Moist
+	}
+}
+
+// StructuredStatus returns the status of the area: the reachability, dryness and moisture reported by its
+// sensor, its irrigation state, and the warnings of its sensor and valves.
+func (c Circuit) StructuredStatus() Status {
+	s := c.SensorData.StructuredStatus()
+	s.setMoisture(c.SensorData.State)
+
+	s.Irrigation = IrrigationState_Idle
+	if c.State.Irrigation {
+		s.Irrigation = IrrigationState_Active
+	}
+
+	for _, key := range valveKeys(c.Valves) {
+		s.addWarnings(c.Valves[key].Data.StructuredStatus().Warnings...)
+	}
+
+	return s
+}
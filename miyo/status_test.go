@@ -0,0 +1,154 @@
+package miyo
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDeviceStructuredStatus(t *testing.T) {
+	cases := []struct {
+		name string
+		dev  Device
+		want Status
+		str  string
+	}{
+		{
+			name: "unreachable valve",
+			dev:  Device{Type: "valve", State: DeviceState{SunWithinWeek: true, ChargingLess: true}},
+			want: Status{Warnings: []Warning{Warning_ChargingLess}},
+			str:  "unreachable; charging less",
+		},
+		{
+			name: "open valve",
+			dev:  Device{Type: "valve", State: DeviceState{Reachable: true, SunWithinWeek: true, ValveStatus: true}},
+			want: Status{Reachability: Reachability_Reachable, Irrigation: IrrigationState_Active},
+			str:  "irrigation active",
+		},
+		{
+			// Values from testdata/device-all.json: valveInitialClose is set on every valve.
+			name: "open valve with initial close",
+			dev: Device{Type: "valve", State: DeviceState{
+				Reachable: true, ChargingLess: true, LastResetType: -1, RSSI: -200, SunWithinWeek: true, ValveInitialClose: true, ValveStatus: true,
+			}},
+			want: Status{Reachability: Reachability_Reachable, Irrigation: IrrigationState_Active, Warnings: []Warning{Warning_ChargingLess}},
+			str:  "irrigation active; charging less",
+		},
+		{
+			name: "valve to be opened",
+			dev:  Device{Type: "valve", State: DeviceState{Reachable: true, SunWithinWeek: true, OpenValve: true, ValveInitialClose: true}},
+			want: Status{Reachability: Reachability_Reachable, Irrigation: IrrigationState_Starting},
+			str:  "irrigation starting",
+		},
+		{
+			name: "idle valve",
+			dev:  Device{Type: "valve", State: DeviceState{Reachable: true, SunWithinWeek: true, ValveInitialClose: true}},
+			want: Status{Reachability: Reachability_Reachable},
+			str:  "reachable",
+		},
+		{
+			name: "dry sensor",
+			dev: Device{Type: "moistureOutdoor", State: DeviceState{
+				Reachable: true, Moisture: 40, IrrigationNecessary: true, IrrigationPossible: true, LowPower: true, OTAUPossible: true,
+			}},
+			want: Status{
+				Reachability: Reachability_Reachable,
+				Dryness:      Dryness_VeryDry,
+				Moisture:     40,
+				Warnings:     []Warning{Warning_LowPower, Warning_NoSun, Warning_OTAPending},
+			},
+			str: "moisture 40%; very dry; low power; no sun; ota pending",
+		},
+		{
+			name: "unreachable sensor",
+			dev:  Device{Type: "moistureOutdoor", State: DeviceState{Moisture: 100, SunWithinWeek: true}},
+			want: Status{},
+			str:  "unreachable",
+		},
+	}
+
+	for _, tc := range cases {
+		got := tc.dev.StructuredStatus()
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("%s: StructuredStatus() differs (-want/+got):\n%s", tc.name, diff)
+		}
+		if got.String() != tc.str {
+			t.Errorf("%s: String() = %q, want %q", tc.name, got.String(), tc.str)
+		}
+	}
+}
+
+func TestCircuitStructuredStatus(t *testing.T) {
+	c := Circuit{
+		SensorData: Device{Type: "moistureOutdoor", State: DeviceState{Reachable: true, SunWithinWeek: true, Moisture: 55, IrrigationPossible: true}},
+		State:      CircuitState{Irrigation: true},
+		Valves: map[string]Valve{
+			"0": {Data: Device{Type: "valve", State: DeviceState{SunWithinWeek: true, LowPower: true}}},
+			"1": {Data: Device{Type: "valve", State: DeviceState{LowPower: true, ChargingLess: true}}},
+		},
+	}
+
+	want := Status{
+		Reachability: Reachability_Reachable,
+		Dryness:      Dryness_Dry,
+		Moisture:     55,
+		Irrigation:   IrrigationState_Active,
+		Warnings:     []Warning{Warning_LowPower, Warning_ChargingLess, Warning_NoSun},
+	}
+	got := c.StructuredStatus()
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("StructuredStatus() differs (-want/+got):\n%s", diff)
+	}
+
+	data, err := json.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantJSON := `{"reachability":"reachable","dryness":"dry","moisture":55,"irrigation":"active","warnings":["low_power","charging_less","no_sun"]}`
+	if string(data) != wantJSON {
+		t.Errorf("json.Marshal() = %s, want %s", data, wantJSON)
+	}
+}
+
+func TestStatusTranslate(t *testing.T) {
+	defer func(fn func(string, string) string) { Translate = fn }(Translate)
+	german := map[string]string{
+		"moisture %d%%": "Feuchte %d%%",
+		"very dry":      "sehr trocken",
+		"irrigation %s": "Bewässerung %s",
+		"active":        "aktiv",
+		"low power":     "Akku schwach",
+		"charging less": "lädt zu wenig",
+		"no sun":        "keine Sonne",
+		"ota pending":   "Update verfügbar",
+		"unreachable":   "nicht erreichbar",
+		"reachable":     "erreichbar",
+	}
+	Translate = func(domain, msg string) string {
+		if s, ok := german[msg]; ok && domain == "miyo" {
+			return s
+		}
+		return msg
+	}
+
+	s := Status{
+		Reachability: Reachability_Reachable,
+		Dryness:      Dryness_VeryDry,
+		Moisture:     40,
+		Irrigation:   IrrigationState_Active,
+		Warnings:     []Warning{Warning_LowPower},
+	}
+	if got, want := s.String(), "Feuchte 40%; sehr trocken; Bewässerung aktiv; Akku schwach"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	// MarshalText is independent of the language.
+	data, err := json.Marshal(s.Dryness)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), `"very_dry"`; got != want {
+		t.Errorf("json.Marshal(%v) = %s, want %s", s.Dryness, got, want)
+	}
+}
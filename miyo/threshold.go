@@ -0,0 +1,129 @@
+package miyo
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Percent is a soil moisture value in percent, from 0 to 100.
+type Percent int
+
+// Validate returns an error if p is outside of 0 to 100.
+func (p Percent) Validate() error {
+	if p < 0 || p > 100 {
+		return fmt.Errorf("%d%% is out of range [0, 100]", p)
+	}
+	return nil
+}
+
+// UnmarshalJSON parses a percentage encoded as number or, as returned by the MIYO Cube, as string. An empty
+// string is zero. The range is checked by CircuitParams.UnmarshalJSON, so that its errors name the field.
+func (p *Percent) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		if s == "" {
+			*p = 0
+			return nil
+		}
+		data = []byte(s)
+	}
+
+	n, err := strconv.Atoi(string(data))
+	if err != nil {
+		return fmt.Errorf("invalid percentage %s", data)
+	}
+
+	*p = Percent(n)
+	return nil
+}
+
+// UnmarshalJSON parses the JSON-encoded data and stores the result in p.
+// It returns an error if the moisture bounds are invalid, see ValidateBounds.
+func (p *CircuitParams) UnmarshalJSON(data []byte) error {
+	// params has the same fields as CircuitParams, but not its methods. This avoids infinite recursion.
+	type params CircuitParams
+
+	var parsed params
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return err
+	}
+	if err := CircuitParams(parsed).ValidateBounds(); err != nil {
+		return err
+	}
+
+	*p = CircuitParams(parsed)
+	return nil
+}
+
+// UnmarshalJSON parses the JSON-encoded data and stores the result in c. Errors, e.g. invalid moisture bounds,
+// name the circuit.
+func (c *Circuit) UnmarshalJSON(data []byte) error {
+	type circuit Circuit
+
+	var parsed circuit
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		var name struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		}
+		if json.Unmarshal(data, &name) == nil && name.ID != "" {
+			return fmt.Errorf("area %q (%s): %w", name.Name, name.ID, err)
+		}
+		return err
+	}
+
+	*c = Circuit(parsed)
+	return nil
+}
+
+// ValidateBounds checks that BorderBottom and BorderTop are within 0 to 100 and BorderBottom is below BorderTop.
+func (p CircuitParams) ValidateBounds() error {
+	if err := p.BorderBottom.Validate(); err != nil {
+		return fmt.Errorf("borderBottom: %w", err)
+	}
+	if err := p.BorderTop.Validate(); err != nil {
+		return fmt.Errorf("borderTop: %w", err)
+	}
+	if p.BorderBottom >= p.BorderTop {
+		return fmt.Errorf("borderBottom (%d%%) must be below borderTop (%d%%)", p.BorderBottom, p.BorderTop)
+	}
+	return nil
+}
+
+// Validate checks the moisture bounds of a circuit built in code, see CircuitParams.ValidateBounds. The error
+// names the circuit.
+func (c Circuit) Validate() error {
+	if err := c.Params.ValidateBounds(); err != nil {
+		return fmt.Errorf("area %q (%s): %w", c.Name, c.ID, err)
+	}
+	return nil
+}
+
+// SetBounds sets BorderBottom and BorderTop. It returns an error and leaves p unchanged if the bounds are invalid.
+func (p *CircuitParams) SetBounds(bottom, top Percent) error {
+	next := *p
+	next.BorderBottom, next.BorderTop = bottom, top
+	if err := next.ValidateBounds(); err != nil {
+		return err
+	}
+
+	*p = next
+	return nil
+}
+
+// BelowLowerBound reports whether moisture is below BorderBottom, i.e. the area needs water.
+func (p CircuitParams) BelowLowerBound(moisture int) bool {
+	return moisture < int(p.BorderBottom)
+}
+
+// WithinBand reports whether moisture is within BorderBottom and BorderTop, inclusively.
+func (p CircuitParams) WithinBand(moisture int) bool {
+	return moisture >= int(p.BorderBottom) && moisture <= int(p.BorderTop)
+}
+
+// DistanceToTarget returns the number of percentage points moisture has to rise to reach BorderTop, the moisture
+// the MIYO Cube irrigates to. It is negative if moisture is above BorderTop.
+func (p CircuitParams) DistanceToTarget(moisture int) int {
+	return int(p.BorderTop) - moisture
+}
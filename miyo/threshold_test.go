@@ -0,0 +1,88 @@
+package miyo
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestCircuitParamsBounds(t *testing.T) {
+	cases := []struct {
+		json    string
+		wantErr string
+	}{
+		{`{"borderBottom": "40", "borderTop": "60"}`, ""},
+		{`{"borderBottom": 40, "borderTop": 60}`, ""},
+		{`{"borderBottom": "60", "borderTop": "40"}`, "borderBottom (60%) must be below borderTop (40%)"},
+		{`{"borderBottom": "50", "borderTop": "50"}`, "borderBottom (50%) must be below borderTop (50%)"},
+		{`{"borderBottom": "-1", "borderTop": "60"}`, "borderBottom: -1% is out of range [0, 100]"},
+		{`{"borderBottom": "40", "borderTop": "101"}`, "borderTop: 101% is out of range [0, 100]"},
+		{`{"borderBottom": "40", "borderTop": ""}`, "borderBottom (40%) must be below borderTop (0%)"},
+		{`{}`, "borderBottom (0%) must be below borderTop (0%)"},
+		{`{"borderBottom": "forty", "borderTop": "60"}`, "invalid percentage forty"},
+	}
+
+	for _, tc := range cases {
+		var p CircuitParams
+		err := json.Unmarshal([]byte(tc.json), &p)
+		if got := errString(err); got != tc.wantErr {
+			t.Errorf("json.Unmarshal(%s) = %q, want %q", tc.json, got, tc.wantErr)
+		}
+	}
+
+	var area Circuit
+	err := json.Unmarshal([]byte(`{"id": "{b85746b6}", "name": "Rosen", "params": {"borderBottom": "60", "borderTop": "40"}}`), &area)
+	if want := `area "Rosen" ({b85746b6}): borderBottom (60%) must be below borderTop (40%)`; errString(err) != want {
+		t.Errorf("json.Unmarshal(area) = %v, want %q", err, want)
+	}
+
+	c := Circuit{ID: "{b85746b6}", Name: "Rosen", Params: CircuitParams{BorderBottom: 60, BorderTop: 40}}
+	want := `area "Rosen" ({b85746b6}): borderBottom (60%) must be below borderTop (40%)`
+	if err := c.Validate(); err == nil || err.Error() != want {
+		t.Errorf("Validate() = %v, want %q", err, want)
+	}
+	if err := (&Conn{}).SetThresholds(context.Background(), c.ID, 60, 40); err == nil {
+		t.Error("SetThresholds(60, 40) succeeded, want error")
+	}
+
+	var p CircuitParams
+	if err := p.SetBounds(40, 60); err != nil {
+		t.Fatalf("SetBounds(40, 60) = %v", err)
+	}
+	if err := p.SetBounds(70, 60); err == nil {
+		t.Error("SetBounds(70, 60) succeeded, want error")
+	}
+	if p.BorderBottom != 40 || p.BorderTop != 60 {
+		t.Errorf("failed SetBounds() changed bounds to %d-%d", p.BorderBottom, p.BorderTop)
+	}
+
+	helpers := []struct {
+		moisture int
+		below    bool
+		within   bool
+		distance int
+	}{
+		{30, true, false, 30},
+		{40, false, true, 20},
+		{60, false, true, 0},
+		{75, false, false, -15},
+	}
+	for _, h := range helpers {
+		if got := p.BelowLowerBound(h.moisture); got != h.below {
+			t.Errorf("BelowLowerBound(%d) = %v, want %v", h.moisture, got, h.below)
+		}
+		if got := p.WithinBand(h.moisture); got != h.within {
+			t.Errorf("WithinBand(%d) = %v, want %v", h.moisture, got, h.within)
+		}
+		if got := p.DistanceToTarget(h.moisture); got != h.distance {
+			t.Errorf("DistanceToTarget(%d) = %d, want %d", h.moisture, got, h.distance)
+		}
+	}
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
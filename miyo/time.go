@@ -0,0 +1,46 @@
+package miyo
+
+import (
+	"time"
+)
+
+// unixTime converts a Unix timestamp as returned by the MIYO Cube. Zero means "never" and returns the zero time.
+// The accessors below return times in time.Local; use In(snap.Location()) for the MIYO Cube's wall clock time.
+func unixTime(ts int) time.Time {
+	if ts == 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(ts), 0)
+}
+
+// Updated returns LastUpdate, the time the MIYO Cube last heard from the device.
+// It returns the zero time if the device was never seen.
+func (d Device) Updated() time.Time {
+	return unixTime(d.LastUpdate)
+}
+
+// IrrigationStarted returns LastIrrigationStart, or the zero time if the valve never opened.
+func (s DeviceState) IrrigationStarted() time.Time {
+	return unixTime(s.LastIrrigationStart)
+}
+
+// IrrigationEnded returns LastIrrigationEnd, or the zero time if the valve never closed after irrigating.
+func (s DeviceState) IrrigationEnded() time.Time {
+	return unixTime(s.LastIrrigationEnd)
+}
+
+// IrrigationDuration returns LastIrrigationDuration, which the MIYO Cube reports in seconds.
+func (s DeviceState) IrrigationDuration() time.Duration {
+	return time.Duration(s.LastIrrigationDuration) * time.Second
+}
+
+// LastReset returns LastResetTime, or the zero time if the device was never reset.
+func (s DeviceState) LastReset() time.Time {
+	return unixTime(s.LastResetTime)
+}
+
+// NextIrrigation returns IrrigationNextStart and IrrigationNextEnd, the next irrigation window planned by the
+// MIYO Cube. Both are zero if no irrigation is planned.
+func (s CircuitState) NextIrrigation() (start, end time.Time) {
+	return unixTime(s.IrrigationNextStart), unixTime(s.IrrigationNextEnd)
+}
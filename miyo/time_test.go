@@ -0,0 +1,60 @@
+package miyo
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestTimeAccessors(t *testing.T) {
+	berlin := time.FixedZone("CEST", 2*60*60)
+
+	d := Device{
+		LastUpdate: 1648642654,
+		State: DeviceState{
+			LastIrrigationStart:    1648701000,
+			LastIrrigationEnd:      1648702800,
+			LastIrrigationDuration: 1800,
+		},
+	}
+
+	if got, want := d.Updated(), time.Unix(1648642654, 0); !got.Equal(want) {
+		t.Errorf("Updated() = %v, want %v", got, want)
+	}
+	if got := d.State.IrrigationEnded().Sub(d.State.IrrigationStarted()); got != d.State.IrrigationDuration() {
+		t.Errorf("IrrigationEnded() - IrrigationStarted() = %v, want %v", got, d.State.IrrigationDuration())
+	}
+	if got := d.State.LastReset(); !got.IsZero() {
+		t.Errorf("LastReset() = %v, want zero time for \"never\"", got)
+	}
+
+	// 2022-03-31 06:30 in the MIYO Cube's time zone, i.e. the start of the Day3 window "06:30-09:00".
+	s := CircuitState{IrrigationNextStart: 1648701000, IrrigationNextEnd: 1648710000}
+	start, end := s.NextIrrigation()
+	if got, want := start.In(berlin).Format("Mon 15:04"), "Thu 06:30"; got != want {
+		t.Errorf("NextIrrigation() start = %q, want %q", got, want)
+	}
+	if got, want := end.Sub(start), 2*time.Hour+30*time.Minute; got != want {
+		t.Errorf("NextIrrigation() duration = %v, want %v", got, want)
+	}
+}
+
+func TestWithLocation(t *testing.T) {
+	berlin := time.FixedZone("CEST", 2*60*60)
+
+	c, err := Connect(context.Background(), "miyo.local", "key", WithLocation(berlin))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := c.Location(); got != berlin {
+		t.Errorf("Location() = %v, want %v", got, berlin)
+	}
+
+	c, err = Connect(context.Background(), "miyo.local", "key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := c.Location(); got != time.Local {
+		t.Errorf("Location() = %v, want time.Local", got)
+	}
+}
@@ -0,0 +1,181 @@
+package miyo
+
+import (
+	"context"
+	"sort"
+)
+
+// Topology links the areas of a MIYO Cube to the devices they use.
+type Topology struct {
+	Circuits []CircuitTopology `json:"circuits"`
+	Devices  DeviceList        `json:"devices"`
+
+	// users maps the reference of a device to the indexes of the circuits using it.
+	users map[DeviceRef][]int
+}
+
+// CircuitTopology holds a circuit and its devices, as found in the device list.
+// Sensor and SensorValve are nil if the device is not in the device list.
+type CircuitTopology struct {
+	Circuit     Circuit    `json:"circuit"`
+	Sensor      *Device    `json:"sensor,omitempty"`
+	SensorValve *Device    `json:"sensorValve,omitempty"`
+	Valves      DeviceList `json:"valves"`
+	// Missing holds the references of the circuit that are not in the device list.
+	Missing []DeviceRef `json:"missing,omitempty"`
+}
+
+// SharedValve is a valve used by more than one circuit.
+type SharedValve struct {
+	Valve    Device    `json:"valve"`
+	Circuits []Circuit `json:"circuits"`
+}
+
+// NewTopology links areas to the devices in devs.
+func NewTopology(areas []Circuit, devs DeviceList) *Topology {
+	t := &Topology{
+		users: map[DeviceRef][]int{},
+	}
+	for _, d := range devs {
+		d.Ref = d.Reference()
+		t.Devices = append(t.Devices, d)
+	}
+	devs = t.Devices
+
+	for i, c := range areas {
+		ct := CircuitTopology{Circuit: c}
+		used := map[DeviceRef]bool{}
+
+		resolve := func(ref DeviceRef) *Device {
+			d, ok := devs.Lookup(ref)
+			if !ok {
+				ct.Missing = append(ct.Missing, ref)
+				return nil
+			}
+			if !used[d.Ref] {
+				used[d.Ref] = true
+				t.users[d.Ref] = append(t.users[d.Ref], i)
+			}
+			return &d
+		}
+
+		if c.Sensor != "" {
+			ct.Sensor = resolve(c.SensorRef())
+		}
+		if c.SensorValve.Valve != "" {
+			ct.SensorValve = resolve(c.SensorValve.Ref())
+		}
+		for _, key := range valveKeys(c.Valves) {
+			if d := resolve(c.Valves[key].Ref()); d != nil {
+				ct.Valves = append(ct.Valves, *d)
+			}
+		}
+
+		t.Circuits = append(t.Circuits, ct)
+	}
+
+	return t
+}
+
+// valveKeys returns the keys of a circuit's valves, i.e. "0", "1", …, in numerical order.
+func valveKeys(valves map[string]Valve) []string {
+	var keys []string
+	for k := range valves {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) < len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// SortedValves returns the valves of the circuit ordered by their key, i.e. "0", "1", ….
+func (c Circuit) SortedValves() []Valve {
+	var ret []Valve
+	for _, key := range valveKeys(c.Valves) {
+		ret = append(ret, c.Valves[key])
+	}
+	return ret
+}
+
+// Topology links the areas of the snapshot to its devices.
+func (s Snapshot) Topology() *Topology {
+	return NewTopology(s.Areas, s.Devices)
+}
+
+// Topology queries all areas and devices from the MIYO Cube and links them.
+func (c *Conn) Topology(ctx context.Context) (*Topology, error) {
+	snap, err := c.Snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return snap.Topology(), nil
+}
+
+// CircuitsOf returns the circuits using the device referred to by ref, as valve or as sensor.
+func (t *Topology) CircuitsOf(ref DeviceRef) []Circuit {
+	d, ok := t.Devices.Lookup(ref)
+	if !ok {
+		return nil
+	}
+
+	var ret []Circuit
+	for _, i := range t.users[d.Ref] {
+		ret = append(ret, t.Circuits[i].Circuit)
+	}
+	return ret
+}
+
+// CircuitOf returns the circuit using the device referred to by ref. If the device is used by several circuits,
+// the first one is returned; use SharedValves to find these.
+func (t *Topology) CircuitOf(ref DeviceRef) (Circuit, bool) {
+	cs := t.CircuitsOf(ref)
+	if len(cs) == 0 {
+		return Circuit{}, false
+	}
+	return cs[0], true
+}
+
+// Orphans returns the devices that are not used by any circuit.
+func (t *Topology) Orphans() DeviceList {
+	var ret DeviceList
+	for _, d := range t.Devices {
+		if len(t.users[d.Ref]) == 0 {
+			ret = append(ret, d)
+		}
+	}
+	return ret
+}
+
+// SharedValves returns the valves that are used by more than one circuit.
+func (t *Topology) SharedValves() []SharedValve {
+	var ret []SharedValve
+	for _, d := range t.Devices.OfType(DeviceType_Valve) {
+		idx := t.users[d.Ref]
+		if len(idx) < 2 {
+			continue
+		}
+
+		sv := SharedValve{Valve: d}
+		for _, i := range idx {
+			sv.Circuits = append(sv.Circuits, t.Circuits[i].Circuit)
+		}
+		ret = append(ret, sv)
+	}
+	return ret
+}
+
+// WithoutSensor returns the circuits that have no reachable moisture sensor, i.e. circuits without a sensor,
+// with a sensor missing from the device list, or with an unreachable sensor.
+func (t *Topology) WithoutSensor() []Circuit {
+	var ret []Circuit
+	for _, ct := range t.Circuits {
+		if ct.Sensor == nil || !ct.Sensor.State.Reachable {
+			ret = append(ret, ct.Circuit)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,109 @@
+package miyo
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTopology(t *testing.T) {
+	var (
+		sensorA = DeviceRef{ID: "{sensor-a}", Channel: 1}
+		sensorB = DeviceRef{ID: "{sensor-b}", Channel: 1}
+		valve1  = DeviceRef{ID: "{valve-1}", Channel: 1}
+		valve2  = DeviceRef{ID: "{valve-2}", Channel: 1}
+		valve3  = DeviceRef{ID: "{valve-3}", Channel: 1}
+		orphan  = DeviceRef{ID: "{valve-4}", Channel: 1}
+	)
+	device := func(ref DeviceRef, typ string, reachable bool) Device {
+		return Device{ID: ref.ID, Channel: ref.Channel, Type: typ, Ref: ref, State: DeviceState{Reachable: reachable}}
+	}
+	devs := DeviceList{
+		device(sensorA, "moistureOutdoor", true),
+		device(sensorB, "moistureOutdoor", false),
+		device(valve1, "valve", true),
+		device(valve2, "valve", true),
+		device(valve3, "valve", true),
+		device(orphan, "valve", true),
+	}
+
+	lawn := Circuit{
+		ID:          "{lawn}",
+		Name:        "Lawn",
+		Sensor:      sensorA.ID,
+		SensorValve: SensorValve{Valve: valve1.ID, Channel: 1},
+		Valves: map[string]Valve{
+			"0": {ID: valve1.ID, Channel: 1, Data: Device{Channel: 3276902}},
+			"1": {ID: valve2.ID, Channel: 1},
+		},
+	}
+	roses := Circuit{
+		ID:          "{roses}",
+		Name:        "Roses",
+		Sensor:      sensorB.ID,
+		SensorValve: SensorValve{Valve: valve3.ID, Channel: 1},
+		Valves: map[string]Valve{
+			"0": {ID: valve3.ID, Channel: 1},
+			"1": {ID: valve2.ID, Channel: 1},
+		},
+	}
+	hedge := Circuit{
+		ID:     "{hedge}",
+		Name:   "Hedge",
+		Sensor: "{gone}",
+	}
+
+	topo := NewTopology([]Circuit{lawn, roses, hedge}, devs)
+
+	names := func(cs []Circuit) []string {
+		var ret []string
+		for _, c := range cs {
+			ret = append(ret, c.Name)
+		}
+		return ret
+	}
+
+	if got, ok := topo.CircuitOf(valve3); !ok || got.Name != "Roses" {
+		t.Errorf("CircuitOf(%v) = %q, %v, want %q", valve3, got.Name, ok, "Roses")
+	}
+	if got, ok := topo.CircuitOf(DeviceRef{ID: sensorA.ID}); !ok || got.Name != "Lawn" {
+		t.Errorf("CircuitOf(%v) = %q, %v, want %q", sensorA.ID, got.Name, ok, "Lawn")
+	}
+	if got, ok := topo.CircuitOf(orphan); ok {
+		t.Errorf("CircuitOf(%v) = %q, want not found", orphan, got.Name)
+	}
+	if diff := cmp.Diff([]string{"Lawn", "Roses"}, names(topo.CircuitsOf(valve2))); diff != "" {
+		t.Errorf("CircuitsOf(%v) differs (-want/+got):\n%s", valve2, diff)
+	}
+
+	var valves []DeviceRef
+	for _, d := range topo.Circuits[0].Valves {
+		valves = append(valves, d.Ref)
+	}
+	if diff := cmp.Diff([]DeviceRef{valve1, valve2}, valves); diff != "" {
+		t.Errorf("Circuits[0].Valves differs (-want/+got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]DeviceRef{{ID: "{gone}"}}, topo.Circuits[2].Missing); diff != "" {
+		t.Errorf("Circuits[2].Missing differs (-want/+got):\n%s", diff)
+	}
+
+	var orphans []DeviceRef
+	for _, d := range topo.Orphans() {
+		orphans = append(orphans, d.Ref)
+	}
+	if diff := cmp.Diff([]DeviceRef{orphan}, orphans); diff != "" {
+		t.Errorf("Orphans() differs (-want/+got):\n%s", diff)
+	}
+
+	shared := topo.SharedValves()
+	if len(shared) != 1 || shared[0].Valve.Ref != valve2 {
+		t.Fatalf("SharedValves() = %v, want exactly %v", shared, valve2)
+	}
+	if diff := cmp.Diff([]string{"Lawn", "Roses"}, names(shared[0].Circuits)); diff != "" {
+		t.Errorf("SharedValves()[0].Circuits differs (-want/+got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff([]string{"Roses", "Hedge"}, names(topo.WithoutSensor())); diff != "" {
+		t.Errorf("WithoutSensor() differs (-want/+got):\n%s", diff)
+	}
+}
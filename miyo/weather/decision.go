@@ -0,0 +1,125 @@
+package weather
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/octo/miyo-go/miyo"
+)
+
+// Policy configures how expected rain affects planned irrigation.
+type Policy struct {
+	// Lookahead extends the time rain is considered before the planned irrigation starts.
+	Lookahead time.Duration
+	// ShortenAbove is the amount of rain in millimeters above which irrigation is shortened.
+	// The duration is reduced proportionally to the rain expected, relative to SkipAbove.
+	ShortenAbove float64
+	// SkipAbove is the amount of rain in millimeters above which irrigation is skipped.
+	SkipAbove float64
+}
+
+// DefaultPolicy skips irrigation if 5 mm of rain or more are expected and shortens it above 1 mm.
+var DefaultPolicy = Policy{
+	Lookahead:    6 * time.Hour,
+	ShortenAbove: 1,
+	SkipAbove:    5,
+}
+
+// Action is the outcome of a decision.
+type Action int
+
+const (
+	// Action_None means that no irrigation is planned.
+	Action_None Action = iota
+	// Action_Irrigate means that irrigation should go ahead as planned.
+	Action_Irrigate
+	// Action_Shorten means that irrigation should be shortened to Decision.Duration.
+	Action_Shorten
+	// Action_Skip means that irrigation should be skipped.
+	Action_Skip
+)
+
+func (a Action) String() string {
+	names := map[Action]string{
+		Action_None:     "none",
+		Action_Irrigate: "irrigate",
+		Action_Shorten:  "shorten",
+		Action_Skip:     "skip",
+	}
+	if name, ok := names[a]; ok {
+		return name
+	}
+	return fmt.Sprintf("Action#%d", a)
+}
+
+// Decision is the recommendation for the next planned irrigation of a circuit.
+type Decision struct {
+	Action Action
+	// Start and End are the planned irrigation window as reported by the MIYO Cube.
+	Start, End time.Time
+	// Rain is the amount of rain in millimeters expected from Lookahead before Start until End.
+	Rain float64
+	// Duration is the recommended irrigation duration.
+	Duration time.Duration
+}
+
+func init() {
+	miyo.RegisterCatalog(domain, "de", miyo.Catalog{
+		"no irrigation planned":                   "keine Bewässerung geplant",
+		"shorten to %v (%.1f mm rain expected)":   "verkürzen auf %v (%.1f mm Regen erwartet)",
+		"skip (%.1f mm rain expected)":            "aussetzen (%.1f mm Regen erwartet)",
+		"irrigate for %v (%.1f mm rain expected)": "%v bewässern (%.1f mm Regen erwartet)",
+	})
+}
+
+// domain is the catalog domain of this package's messages.
+const domain = "weather"
+
+// tr translates msg of this package.
+func tr(msg string) string {
+	return miyo.Translate(domain, msg)
+}
+
+// String formats the decision, translated into the current language.
+func (d Decision) String() string {
+	switch d.Action {
+	case Action_None:
+		return tr("no irrigation planned")
+	case Action_Shorten:
+		return fmt.Sprintf(tr("shorten to %v (%.1f mm rain expected)"), d.Duration, d.Rain)
+	case Action_Skip:
+		return fmt.Sprintf(tr("skip (%.1f mm rain expected)"), d.Rain)
+	default:
+		return fmt.Sprintf(tr("irrigate for %v (%.1f mm rain expected)"), d.Duration, d.Rain)
+	}
+}
+
+// Decide recommends whether the next irrigation planned by the MIYO Cube for c, as given by
+// IrrigationNextStart and IrrigationNextEnd, should go ahead, be shortened or be skipped.
+func Decide(c miyo.Circuit, f Forecast, p Policy) Decision {
+	start, end := c.State.NextIrrigation()
+	if start.IsZero() || !end.After(start) {
+		return Decision{Action: Action_None}
+	}
+
+	d := Decision{
+		Action: Action_Irrigate,
+		Start:  start,
+		End:    end,
+	}
+	d.Rain = f.Rain(d.Start.Add(-p.Lookahead), d.End)
+	d.Duration = d.End.Sub(d.Start)
+
+	switch {
+	case p.SkipAbove > 0 && d.Rain >= p.SkipAbove:
+		d.Action = Action_Skip
+		d.Duration = 0
+	case p.ShortenAbove > 0 && d.Rain >= p.ShortenAbove:
+		d.Action = Action_Shorten
+		if p.SkipAbove > 0 {
+			d.Duration = time.Duration(float64(d.Duration) * (1 - d.Rain/p.SkipAbove)).Round(time.Minute)
+		}
+	}
+
+	return d
+}
@@ -0,0 +1,114 @@
+// Package weather makes irrigation decisions based on a local weather forecast.
+//
+// The MIYO Cube's ConsiderWeather and IrrigationDelayForecast parameters rely on a cloud service that can't be
+// inspected. This package reads forecasts from a Provider instead, for example a JSON file or a local HTTP
+// service, in the following format:
+//
+//	{"hours": [
+//	  {"time": "2022-04-04T10:00:00Z", "precipitation": 1.5},
+//	  {"time": "2022-04-04T11:00:00Z", "precipitation": 0.2}
+//	]}
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+// Provider returns weather forecasts.
+type Provider interface {
+	Forecast(ctx context.Context) (Forecast, error)
+}
+
+// Forecast is an hourly weather forecast.
+type Forecast struct {
+	Hours []Hour `json:"hours"`
+}
+
+// Hour is the forecast for the hour starting at Time.
+type Hour struct {
+	Time time.Time `json:"time"`
+	// Precipitation is the expected amount of rain in millimeters (liters per square meter).
+	Precipitation float64 `json:"precipitation"`
+}
+
+// Rain returns the expected amount of rain in millimeters between from and to.
+// Hours partially overlapping the interval are counted proportionally.
+func (f Forecast) Rain(from, to time.Time) float64 {
+	var sum float64
+	for _, h := range f.Hours {
+		start, end := h.Time, h.Time.Add(time.Hour)
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+		if !end.After(start) {
+			continue
+		}
+		sum += h.Precipitation * float64(end.Sub(start)) / float64(time.Hour)
+	}
+	return sum
+}
+
+// FileProvider reads the forecast from a JSON file on every call.
+type FileProvider struct {
+	FileName string
+}
+
+// Forecast implements Provider.
+func (p FileProvider) Forecast(_ context.Context) (Forecast, error) {
+	f, err := os.Open(p.FileName)
+	if err != nil {
+		return Forecast{}, err
+	}
+	defer f.Close()
+
+	return decode(f)
+}
+
+// HTTPProvider fetches the forecast from a URL returning JSON.
+type HTTPProvider struct {
+	URL string
+	// Client is used for requests. If nil, http.DefaultClient is used.
+	Client *http.Client
+}
+
+// Forecast implements Provider.
+func (p HTTPProvider) Forecast(ctx context.Context) (Forecast, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.URL, nil)
+	if err != nil {
+		return Forecast{}, err
+	}
+
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return Forecast{}, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return Forecast{}, fmt.Errorf("GET %s: %s", p.URL, res.Status)
+	}
+
+	return decode(res.Body)
+}
+
+func decode(r io.Reader) (Forecast, error) {
+	var f Forecast
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return Forecast{}, fmt.Errorf("decoding forecast: %w", err)
+	}
+	return f, nil
+}
@@ -0,0 +1,93 @@
+package weather
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/octo/miyo-go/miyo"
+)
+
+const forecastJSON = `{"hours": [
+  {"time": "2022-04-04T04:00:00Z", "precipitation": 0.5},
+  {"time": "2022-04-04T05:00:00Z", "precipitation": 1.0},
+  {"time": "2022-04-04T06:00:00Z", "precipitation": 2.0}
+]}`
+
+func TestProviders(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "forecast.json")
+	if err := os.WriteFile(fileName, []byte(forecastJSON), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(forecastJSON))
+	}))
+	defer srv.Close()
+
+	providers := map[string]Provider{
+		"file": FileProvider{FileName: fileName},
+		"http": HTTPProvider{URL: srv.URL},
+	}
+
+	for name, p := range providers {
+		f, err := p.Forecast(context.Background())
+		if err != nil {
+			t.Errorf("%s: Forecast() = %v", name, err)
+			continue
+		}
+		if got, want := len(f.Hours), 3; got != want {
+			t.Errorf("%s: len(Hours) = %d, want %d", name, got, want)
+		}
+	}
+}
+
+func TestDecide(t *testing.T) {
+	var f Forecast
+	f.Hours = []Hour{
+		{Time: time.Date(2022, 4, 4, 4, 0, 0, 0, time.UTC), Precipitation: 0.5},
+		{Time: time.Date(2022, 4, 4, 5, 0, 0, 0, time.UTC), Precipitation: 1.0},
+		{Time: time.Date(2022, 4, 4, 6, 0, 0, 0, time.UTC), Precipitation: 2.0},
+		{Time: time.Date(2022, 4, 4, 20, 0, 0, 0, time.UTC), Precipitation: 8.0},
+	}
+
+	circuit := func(start time.Time, d time.Duration) miyo.Circuit {
+		var c miyo.Circuit
+		if !start.IsZero() {
+			c.State.IrrigationNextStart = int(start.Unix())
+			c.State.IrrigationNextEnd = int(start.Add(d).Unix())
+		}
+		return c
+	}
+
+	policy := Policy{
+		Lookahead:    time.Hour,
+		ShortenAbove: 1,
+		SkipAbove:    5,
+	}
+
+	cases := []struct {
+		name string
+		c    miyo.Circuit
+		want string
+	}{
+		{"nothing planned", circuit(time.Time{}, 0), "no irrigation planned"},
+		{"dry", circuit(time.Date(2022, 4, 4, 12, 0, 0, 0, time.UTC), 20*time.Minute), "irrigate for 20m0s (0.0 mm rain expected)"},
+		// 1.0 mm from 05:00 (lookahead) plus 2.0 * 0.5 mm from 06:00 to 06:30.
+		{"some rain", circuit(time.Date(2022, 4, 4, 6, 0, 0, 0, time.UTC), 30*time.Minute), "shorten to 18m0s (2.0 mm rain expected)"},
+		{"heavy rain", circuit(time.Date(2022, 4, 4, 20, 0, 0, 0, time.UTC), time.Hour), "skip (8.0 mm rain expected)"},
+	}
+
+	for _, tc := range cases {
+		got := Decide(tc.c, f, policy).String()
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("%s: Decide() differs (-want/+got):\n%s", tc.name, diff)
+		}
+	}
+}
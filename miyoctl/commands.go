@@ -0,0 +1,477 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/octo/miyo-go/miyo"
+	"github.com/octo/miyo-go/miyo/advisor"
+	"github.com/octo/miyo-go/miyo/graph"
+	"github.com/octo/miyo-go/miyo/health"
+)
+
+func runDiscover(ctx context.Context, e *env, args []string) error {
+	addr, err := miyo.FindCube(ctx)
+	if err != nil {
+		return err
+	}
+
+	return e.out.print(map[string]string{"address": addr}, func(w io.Writer) {
+		fmt.Fprintln(w, addr)
+	})
+}
+
+func runPair(ctx context.Context, e *env, args []string) error {
+	cfg := e.cfg
+	if cfg.Address == "" {
+		addr, err := miyo.FindCube(ctx)
+		if err != nil {
+			return fmt.Errorf("FindCube: %w", err)
+		}
+		cfg.Address = addr
+	}
+
+	key, err := miyo.APIKey(ctx, cfg.Address)
+	if err != nil {
+		return fmt.Errorf("%w (press the physical button on the MIYO Cube and try again)", err)
+	}
+	cfg.APIKey = key
+
+	if err := writeConfig(*configFile, cfg); err != nil {
+		return err
+	}
+
+	fmt.Printf(tr("Configuration written to %s")+"\n", *configFile)
+	return nil
+}
+
+func runAreas(ctx context.Context, e *env, args []string) error {
+	conn, err := e.conn(ctx)
+	if err != nil {
+		return err
+	}
+
+	areas, err := conn.Areas(ctx)
+	if err != nil {
+		return err
+	}
+
+	return e.out.print(areas, func(w io.Writer) {
+		heading(w, "NAME", "ID", "STATUS", "NEXT IRRIGATION")
+		for _, a := range areas {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", a.Name, a.ID, a.Status(), e.formatTime(nextStart(a)))
+		}
+	})
+}
+
+func runDevices(ctx context.Context, e *env, args []string) error {
+	conn, err := e.conn(ctx)
+	if err != nil {
+		return err
+	}
+
+	devs, err := conn.Devices(ctx)
+	if err != nil {
+		return err
+	}
+
+	return e.out.print(devs, func(w io.Writer) {
+		heading(w, "TYPE", "ID", "STATUS", "RSSI", "LOW POWER", "LAST UPDATE")
+		for _, d := range devs {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%s\n", deviceType(d), d.ID, d.Status(), d.State.RSSI, formatBool(d.State.LowPower), e.formatTime(d.Updated()))
+		}
+	})
+}
+
+func runArea(ctx context.Context, e *env, args []string) error {
+	if len(args) != 2 || args[0] != "show" {
+		return usageError{}
+	}
+
+	conn, err := e.conn(ctx)
+	if err != nil {
+		return err
+	}
+
+	a, err := findArea(ctx, conn, args[1])
+	if err != nil {
+		return err
+	}
+
+	return e.out.print(a, func(w io.Writer) {
+		label(w, "Name", "%s", a.Name)
+		label(w, "ID", "%s", a.ID)
+		label(w, "Status", "%s", a.Status())
+		label(w, "Automatic mode", "%s", formatBool(a.State.AutomaticMode))
+		label(w, "Moisture bounds", "%d%% - %d%%", a.Params.BorderBottom, a.Params.BorderTop)
+		label(w, "Soil type", "%s", a.Params.SoilType)
+		start, end := a.State.NextIrrigation()
+		label(w, "Next irrigation", "%s - %s", e.formatTime(start), e.formatTime(end))
+		label(w, "Sensor", "%s (%s)", a.Sensor, a.SensorData.Status())
+		for _, v := range a.SortedValves() {
+			label(w, "Valve", "%s (%s)", v.ID, v.Data.Status())
+		}
+	})
+}
+
+func runValve(ctx context.Context, e *env, args []string) error {
+	if len(args) != 2 || (args[0] != "open" && args[0] != "close") {
+		return usageError{}
+	}
+
+	conn, err := e.conn(ctx)
+	if err != nil {
+		return err
+	}
+
+	d, err := findDevice(ctx, conn, args[1])
+	if err != nil {
+		return err
+	}
+	if d.DeviceType() != miyo.DeviceType_Valve {
+		return fmt.Errorf("device %s is a %s, not a valve", d.Reference(), d.DeviceType())
+	}
+
+	return conn.SetValve(ctx, d.Reference(), args[0] == "open")
+}
+
+func runIrrigate(ctx context.Context, e *env, args []string) error {
+	if len(args) < 1 || len(args) > 2 {
+		return usageError{}
+	}
+
+	start := true
+	if len(args) == 2 {
+		switch args[1] {
+		case "start":
+		case "stop":
+			start = false
+		default:
+			return usageError{}
+		}
+	}
+
+	conn, err := e.conn(ctx)
+	if err != nil {
+		return err
+	}
+
+	a, err := findArea(ctx, conn, args[0])
+	if err != nil {
+		return err
+	}
+
+	return conn.SetIrrigation(ctx, a.ID, start)
+}
+
+func runSchedule(ctx context.Context, e *env, args []string) error {
+	if len(args) < 2 {
+		return usageError{}
+	}
+
+	switch {
+	case args[0] == "get" && len(args) == 2:
+	case args[0] == "set" && len(args) == 4:
+	default:
+		return usageError{}
+	}
+
+	conn, err := e.conn(ctx)
+	if err != nil {
+		return err
+	}
+
+	a, err := findArea(ctx, conn, args[1])
+	if err != nil {
+		return err
+	}
+
+	if args[0] == "get" {
+		schedule := a.Params.Schedule()
+		return e.out.print(schedule, func(w io.Writer) {
+			heading(w, "DAY", "WINDOWS")
+			for i, windows := range schedule {
+				fmt.Fprintf(w, "%d\t%s\n", i, windows)
+			}
+		})
+	}
+
+	if _, err := miyo.ParseWindows(args[3]); err != nil {
+		return err
+	}
+
+	days := []int{0, 1, 2, 3, 4, 5, 6}
+	if args[2] != "all" {
+		day, err := strconv.Atoi(args[2])
+		if err != nil {
+			return usageError{}
+		}
+		days = []int{day}
+	}
+
+	for _, day := range days {
+		if err := conn.SetSchedule(ctx, a.ID, day, args[3]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func runWatch(ctx context.Context, e *env, args []string) error {
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	interval := fs.Duration("interval", time.Minute, "poll interval")
+	if err := fs.Parse(args); err != nil {
+		return usageError{}
+	}
+
+	conn, err := e.conn(ctx)
+	if err != nil {
+		return err
+	}
+
+	return conn.Poll(ctx, *interval, func(snap miyo.Snapshot) error {
+		return e.out.print(snap, func(w io.Writer) {
+			for _, a := range snap.Areas {
+				fmt.Fprintf(w, "%s\t%s\t%s\n", snap.Time.Format(time.RFC3339), a.Name, a.Status())
+			}
+		})
+	})
+}
+
+func runExport(ctx context.Context, e *env, args []string) error {
+	conn, err := e.conn(ctx)
+	if err != nil {
+		return err
+	}
+
+	snap, err := conn.Snapshot(ctx)
+	if err != nil {
+		return err
+	}
+
+	out := e.out
+	if out.format == "table" {
+		// A table can't hold the entire state; default to JSON.
+		out.format = "json"
+	}
+	return out.print(snap, nil)
+}
+
+func runAdvise(ctx context.Context, e *env, args []string) error {
+	if len(args) > 1 {
+		return usageError{}
+	}
+
+	conn, err := e.conn(ctx)
+	if err != nil {
+		return err
+	}
+
+	var areas []miyo.Circuit
+	if len(args) == 1 {
+		a, err := findArea(ctx, conn, args[0])
+		if err != nil {
+			return err
+		}
+		areas = []miyo.Circuit{a}
+	} else {
+		areas, err = conn.Areas(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	type areaAdvice struct {
+		Area           string                 `json:"area"`
+		Recommendation advisor.Recommendation `json:"recommendation"`
+		Differences    []advisor.Difference   `json:"differences"`
+	}
+	var advice []areaAdvice
+	for _, a := range areas {
+		adv := advisor.Advise(a)
+		advice = append(advice, areaAdvice{a.Name, adv.Recommendation, adv.Differences})
+	}
+
+	return e.out.print(advice, func(w io.Writer) {
+		heading(w, "AREA", "PARAM", "CURRENT", "RECOMMENDED", "REASON")
+		for _, a := range advice {
+			if len(a.Differences) == 0 {
+				fmt.Fprintf(w, "%s\t-\t\t\t%s\n", a.Area, tr("matches recommendation"))
+			}
+			for _, d := range a.Differences {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", a.Area, d.Param, d.Current, d.Recommended, d.Reason)
+			}
+		}
+	})
+}
+
+func runTopology(ctx context.Context, e *env, args []string) error {
+	if len(args) != 0 {
+		return usageError{}
+	}
+
+	conn, err := e.conn(ctx)
+	if err != nil {
+		return err
+	}
+
+	topo, err := conn.Topology(ctx)
+	if err != nil {
+		return err
+	}
+
+	type problems struct {
+		Orphans       miyo.DeviceList    `json:"orphans"`
+		SharedValves  []miyo.SharedValve `json:"sharedValves"`
+		WithoutSensor []miyo.Circuit     `json:"withoutSensor"`
+	}
+	v := struct {
+		*miyo.Topology
+		Problems problems `json:"problems"`
+	}{
+		Topology: topo,
+		Problems: problems{topo.Orphans(), topo.SharedValves(), topo.WithoutSensor()},
+	}
+
+	return e.out.print(v, func(w io.Writer) {
+		heading(w, "AREA", "ROLE", "DEVICE", "STATUS")
+		for _, ct := range topo.Circuits {
+			if ct.Sensor != nil {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", ct.Circuit.Name, tr("sensor"), ct.Sensor.Ref, ct.Sensor.Status())
+			}
+			for _, d := range ct.Valves {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", ct.Circuit.Name, tr("valve"), d.Ref, d.Status())
+			}
+			for _, ref := range ct.Missing {
+				fmt.Fprintf(w, "%s\t-\t%s\t%s\n", ct.Circuit.Name, ref, tr("not in device list"))
+			}
+		}
+		for _, d := range v.Problems.Orphans {
+			fmt.Fprintf(w, "-\t%s\t%s\t%s\n", deviceType(d), d.Ref, tr("not used by any area"))
+		}
+		for _, sv := range v.Problems.SharedValves {
+			var names []string
+			for _, c := range sv.Circuits {
+				names = append(names, c.Name)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", strings.Join(names, ", "), tr("valve"), sv.Valve.Ref, tr("shared"))
+		}
+		for _, c := range v.Problems.WithoutSensor {
+			fmt.Fprintf(w, "%s\t-\t-\t%s\n", c.Name, tr("no reachable sensor"))
+		}
+	})
+}
+
+func runGraph(ctx context.Context, e *env, args []string) error {
+	if len(args) != 1 {
+		return usageError{}
+	}
+
+	var write func(io.Writer, *miyo.Topology) error
+	switch args[0] {
+	case "dot":
+		write = graph.WriteDOT
+	case "mermaid":
+		write = graph.WriteMermaid
+	default:
+		return usageError{}
+	}
+
+	conn, err := e.conn(ctx)
+	if err != nil {
+		return err
+	}
+
+	topo, err := conn.Topology(ctx)
+	if err != nil {
+		return err
+	}
+
+	return write(e.out.w, topo)
+}
+
+func runDoctor(ctx context.Context, e *env, args []string) error {
+	if len(args) != 0 {
+		return usageError{}
+	}
+
+	conn, err := e.conn(ctx)
+	if err != nil {
+		return err
+	}
+
+	snap, err := conn.Snapshot(ctx)
+	if err != nil {
+		return err
+	}
+
+	report := health.Diagnose(snap, health.DefaultThresholds)
+	return e.out.print(report, func(w io.Writer) {
+		heading(w, "GRADE", "DEVICE", "PROBLEM", "ADVICE")
+		for _, d := range report.Devices {
+			if len(d.Findings) == 0 {
+				fmt.Fprintf(w, "%s\t%s\t-\t\n", d.Grade, d.Name)
+			}
+			for _, f := range d.Findings {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", f.Grade, d.Name, f.Message, f.Advice)
+			}
+		}
+		fmt.Fprintf(w, "\n%s:\t%s\n", tr("Overall"), report.Grade)
+	})
+}
+
+// findArea returns the area with the given ID or name. Names are compared case-insensitively.
+// findDevice returns the device referred to by ref, e.g. "{uuid};1". The channel may be omitted if the unit has
+// only one.
+func findDevice(ctx context.Context, conn *miyo.Conn, ref string) (miyo.Device, error) {
+	r, err := miyo.ParseDeviceRef(ref)
+	if err != nil {
+		return miyo.Device{}, err
+	}
+
+	devs, err := conn.Devices(ctx)
+	if err != nil {
+		return miyo.Device{}, err
+	}
+
+	var found []string
+	var ret miyo.Device
+	for _, d := range devs {
+		if r.Matches(d.Reference()) {
+			found = append(found, d.Reference().String())
+			ret = d
+		}
+	}
+	switch len(found) {
+	case 0:
+		return miyo.Device{}, fmt.Errorf("device %s not found", r)
+	case 1:
+		return ret, nil
+	default:
+		return miyo.Device{}, fmt.Errorf("device %s has several channels, use one of %s", r, strings.Join(found, ", "))
+	}
+}
+
+func findArea(ctx context.Context, conn *miyo.Conn, nameOrID string) (miyo.Circuit, error) {
+	areas, err := conn.Areas(ctx)
+	if err != nil {
+		return miyo.Circuit{}, err
+	}
+
+	if a, ok := areas.Find(nameOrID); ok {
+		return a, nil
+	}
+
+	return miyo.Circuit{}, fmt.Errorf("area %q not found", nameOrID)
+}
+
+func nextStart(a miyo.Circuit) time.Time {
+	start, _ := a.State.NextIrrigation()
+	return start
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// config holds the settings read from the config file.
+type config struct {
+	Address string `yaml:"address"`
+	APIKey  string `yaml:"apiKey"`
+	// TimeZone is the IANA time zone of the MIYO Cube, e.g. "Europe/Berlin". Defaults to the local time zone.
+	TimeZone string `yaml:"timeZone,omitempty"`
+	// Language is the language of the output, e.g. "de". Defaults to the language of the locale.
+	Language string `yaml:"language,omitempty"`
+}
+
+// defaultConfigFile returns the path of the config file, usually "~/.config/miyo/config.yaml".
+func defaultConfigFile() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "miyo", "config.yaml")
+}
+
+// readConfig reads the config file. A missing file is not an error.
+func readConfig(fileName string) (config, error) {
+	var cfg config
+	if fileName == "" {
+		return cfg, nil
+	}
+
+	data, err := os.ReadFile(fileName)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, err
+	}
+
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// writeConfig writes cfg to the config file, creating its directory if necessary.
+func writeConfig(fileName string, cfg config) error {
+	if fileName == "" {
+		return errors.New("no config file")
+	}
+
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(fileName), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(fileName, data, 0600)
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/octo/miyo-go/miyo"
+)
+
+// domain is the catalog domain of the command's messages.
+const domain = "miyoctl"
+
+func init() {
+	miyo.RegisterCatalog(domain, "de", german)
+}
+
+// german is the German catalog of the command's headings, labels and help texts.
+var german = miyo.Catalog{
+	// Table headings
+	"NEXT IRRIGATION": "NÄCHSTE BEWÄSSERUNG",
+	"TYPE":            "TYP",
+	"LOW POWER":       "AKKU SCHWACH",
+	"LAST UPDATE":     "LETZTE MELDUNG",
+	"DAY":             "TAG",
+	"WINDOWS":         "ZEITFENSTER",
+	"AREA":            "BEREICH",
+	"PARAM":           "PARAMETER",
+	"CURRENT":         "AKTUELL",
+	"RECOMMENDED":     "EMPFOHLEN",
+	"REASON":          "BEGRÜNDUNG",
+	"ROLE":            "ROLLE",
+	"DEVICE":          "GERÄT",
+	"GRADE":           "BEWERTUNG",
+	"ADVICE":          "EMPFEHLUNG",
+	"Overall":         "Gesamt",
+
+	// Labels of "area show"
+	"Automatic mode":  "Automatikmodus",
+	"Moisture bounds": "Feuchtegrenzen",
+	"Soil type":       "Bodenart",
+	"Next irrigation": "Nächste Bewässerung",
+	"Valve":           "Ventil",
+
+	// Values
+	"yes":                    "ja",
+	"no":                     "nein",
+	"sensor":                 "Sensor",
+	"valve":                  "Ventil",
+	"moisture_sensor":        "Feuchtesensor",
+	"shared":                 "gemeinsam genutzt",
+	"not in device list":     "nicht in der Geräteliste",
+	"not used by any area":   "keinem Bereich zugeordnet",
+	"no reachable sensor":    "kein erreichbarer Sensor",
+	"matches recommendation": "entspricht der Empfehlung",
+
+	// Messages and usage
+	"Configuration written to %s":        "Konfiguration in %s gespeichert",
+	"unknown command %q":                 "unbekannter Befehl %q",
+	"Usage: %s [flags] <command> [args]": "Aufruf: %s [Optionen] <Befehl> [Argumente]",
+	"Usage: %s %s":                       "Aufruf: %s %s",
+	"Commands:":                          "Befehle:",
+	"Flags:":                             "Optionen:",
+
+	// Help texts of the commands
+	"find the MIYO Cube on the local network":                          "MIYO Cube im lokalen Netz suchen",
+	"request an API key and store it in the config file":               "API-Schlüssel anfordern und in der Konfigurationsdatei speichern",
+	"list all irrigation areas":                                        "alle Bewässerungsbereiche auflisten",
+	"list all valves and sensors":                                      "alle Ventile und Sensoren auflisten",
+	"show details of one irrigation area":                              "Details eines Bewässerungsbereichs anzeigen",
+	"open or close a valve":                                            "Ventil öffnen oder schließen",
+	"start or stop irrigating an area":                                 "Bewässerung eines Bereichs starten oder stoppen",
+	"show or change the irrigation schedule of an area":                "Bewässerungsplan eines Bereichs anzeigen oder ändern",
+	"print the status of all areas periodically":                       "Status aller Bereiche regelmäßig ausgeben",
+	"print the state of all areas and devices":                         "Zustand aller Bereiche und Geräte ausgeben",
+	"compare thresholds and schedules to recommendations":              "Grenzwerte und Zeitpläne mit Empfehlungen vergleichen",
+	"show which valves and sensors belong to which area":               "anzeigen, welche Ventile und Sensoren zu welchem Bereich gehören",
+	"print the areas, valves and sensors as Graphviz or Mermaid graph": "Bereiche, Ventile und Sensoren als Graphviz- oder Mermaid-Graph ausgeben",
+	"check all valves and sensors for problems":                        "alle Ventile und Sensoren auf Probleme prüfen",
+}
+
+// tr translates msg into the current language.
+func tr(msg string) string {
+	return miyo.Translate(domain, msg)
+}
+
+// deviceType returns the translated type of d, or the type reported by the MIYO Cube if it is unknown.
+func deviceType(d miyo.Device) string {
+	if d.DeviceType() == miyo.DeviceType_Unknown {
+		return d.Type
+	}
+	return tr(d.DeviceType().String())
+}
+
+// heading writes the translated column headings of a table.
+func heading(w io.Writer, columns ...string) {
+	var translated []string
+	for _, c := range columns {
+		translated = append(translated, tr(c))
+	}
+	fmt.Fprintln(w, strings.Join(translated, "\t"))
+}
+
+// label writes one translated "label: value" line.
+func label(w io.Writer, name, format string, args ...interface{}) {
+	fmt.Fprintf(w, "%s:\t%s\n", tr(name), fmt.Sprintf(format, args...))
+}
+
+// formatBool formats b as translated "yes" or "no".
+func formatBool(b bool) string {
+	if b {
+		return tr("yes")
+	}
+	return tr("no")
+}
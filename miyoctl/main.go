@@ -0,0 +1,158 @@
+// miyoctl is a command line tool for querying and controlling a MIYO Cube.
+//
+// The address and API key of the MIYO Cube are read from the "-addr" and "-apikey" flags, the MIYO_ADDRESS and
+// MIYO_APIKEY environment variables, or the config file, in this order. "miyoctl pair" writes the config file.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/octo/miyo-go/miyo"
+)
+
+var (
+	address    = flag.String("addr", os.Getenv("MIYO_ADDRESS"), "address of the Miyo cube")
+	apiKey     = flag.String("apikey", os.Getenv("MIYO_APIKEY"), "API key of the Miyo cube")
+	configFile = flag.String("config", defaultConfigFile(), "config file")
+	output     = flag.String("o", "table", `output format: "table", "json" or "yaml"`)
+	timeZone   = flag.String("tz", "", `time zone of the Miyo cube, e.g. "Europe/Berlin"`)
+	language   = flag.String("lang", "", `language of the output, e.g. "de" or "en"; defaults to the locale`)
+)
+
+// env holds the state shared by all commands.
+type env struct {
+	cfg config
+	out printer
+	// loc is the time zone of the MIYO Cube.
+	loc *time.Location
+}
+
+// conn connects to the MIYO Cube using the configured address, API key and time zone.
+func (e *env) conn(ctx context.Context) (*miyo.Conn, error) {
+	if e.cfg.APIKey == "" {
+		return nil, errors.New(`no API key configured; run "miyoctl pair" first`)
+	}
+	return miyo.Connect(ctx, e.cfg.Address, e.cfg.APIKey, miyo.WithLocation(e.loc))
+}
+
+// formatTime formats a timestamp returned by the MIYO Cube as wall clock time of the MIYO Cube. The zero time,
+// meaning "never", is formatted as "-".
+func (e *env) formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.In(e.loc).Format("2006-01-02 15:04")
+}
+
+type command struct {
+	usage string
+	help  string
+	run   func(ctx context.Context, e *env, args []string) error
+}
+
+var commands = map[string]command{
+	"discover": {"discover", "find the MIYO Cube on the local network", runDiscover},
+	"pair":     {"pair", "request an API key and store it in the config file", runPair},
+	"areas":    {"areas", "list all irrigation areas", runAreas},
+	"devices":  {"devices", "list all valves and sensors", runDevices},
+	"area":     {"area show <name|id>", "show details of one irrigation area", runArea},
+	"valve":    {"valve open|close <id>[;<channel>]", "open or close a valve", runValve},
+	"irrigate": {"irrigate <name|id> [start|stop]", "start or stop irrigating an area", runIrrigate},
+	"schedule": {"schedule get <name|id> | schedule set <name|id> <day|all> <windows>", "show or change the irrigation schedule of an area", runSchedule},
+	"watch":    {"watch [-interval=<duration>]", "print the status of all areas periodically", runWatch},
+	"export":   {"export", "print the state of all areas and devices", runExport},
+	"advise":   {"advise [<name|id>]", "compare thresholds and schedules to recommendations", runAdvise},
+	"topology": {"topology", "show which valves and sensors belong to which area", runTopology},
+	"graph":    {"graph dot|mermaid", "print the areas, valves and sensors as Graphviz or Mermaid graph", runGraph},
+	"doctor":   {"doctor", "check all valves and sensors for problems", runDoctor},
+}
+
+func usage() {
+	fmt.Fprintf(flag.CommandLine.Output(), tr("Usage: %s [flags] <command> [args]")+"\n\n%s\n", os.Args[0], tr("Commands:"))
+
+	var names []string
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		cmd := commands[name]
+		fmt.Fprintf(flag.CommandLine.Output(), "  %s\n    \t%s\n", cmd.usage, tr(cmd.help))
+	}
+
+	fmt.Fprintln(flag.CommandLine.Output(), "\n"+tr("Flags:"))
+	flag.PrintDefaults()
+}
+
+func main() {
+	ctx := context.Background()
+	miyo.SetLanguage(miyo.LanguageFromEnv())
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	cmd, ok := commands[flag.Arg(0)]
+	if !ok {
+		fmt.Fprintf(os.Stderr, tr("unknown command %q")+"\n", flag.Arg(0))
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	cfg, err := readConfig(*configFile)
+	if err != nil {
+		log.Fatalf("reading config: %v", err)
+	}
+	if *address != "" {
+		cfg.Address = *address
+	}
+	if *apiKey != "" {
+		cfg.APIKey = *apiKey
+	}
+	if *timeZone != "" {
+		cfg.TimeZone = *timeZone
+	}
+	if *language != "" {
+		cfg.Language = *language
+	}
+	if cfg.Language != "" {
+		miyo.SetLanguage(cfg.Language)
+	}
+	loc, err := miyo.LoadLocation(cfg.TimeZone)
+	if err != nil {
+		log.Fatalf("time zone: %v", err)
+	}
+
+	e := &env{
+		cfg: cfg,
+		loc: loc,
+		out: printer{
+			format: *output,
+			w:      os.Stdout,
+		},
+	}
+
+	if err := cmd.run(ctx, e, flag.Args()[1:]); err != nil {
+		var uerr usageError
+		if errors.As(err, &uerr) {
+			fmt.Fprintf(os.Stderr, tr("Usage: %s %s")+"\n", os.Args[0], cmd.usage)
+			os.Exit(1)
+		}
+		log.Fatalf("%s: %v", flag.Arg(0), err)
+	}
+}
+
+// usageError is returned by commands that were called with invalid arguments.
+type usageError struct{}
+
+func (usageError) Error() string { return "invalid arguments" }
@@ -0,0 +1,82 @@
+// moisture-forecast predicts, based on the history written by recorder, when each area will need water.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/octo/miyo-go/miyo"
+	"github.com/octo/miyo-go/miyo/forecast"
+	"github.com/octo/miyo-go/miyo/history"
+)
+
+var (
+	address  = flag.String("addr", os.Getenv("MIYO_ADDRESS"), "address of the Miyo cube")
+	apiKey   = flag.String("apikey", os.Getenv("MIYO_APIKEY"), "API key of the Miyo cube")
+	timeZone = flag.String("tz", "", `time zone of the Miyo cube, e.g. "Europe/Berlin"`)
+	dir      = flag.String("dir", "miyo-history", "directory of the history store")
+	window   = flag.Duration("window", 48*time.Hour, "duration of history used for the prediction")
+)
+
+// domain is the catalog domain of the command's messages.
+const domain = "moisture-forecast"
+
+func init() {
+	miyo.RegisterCatalog(domain, "de", miyo.Catalog{
+		"Usage: %s -addr=<addr> -apikey=<apikey> [-dir=<dir>]": "Aufruf: %s -addr=<Adresse> -apikey=<API-Schlüssel> [-dir=<Verzeichnis>]",
+		"Mon 15:04": "02.01. 15:04",
+	})
+}
+
+// tr translates msg into the current language.
+func tr(msg string) string {
+	return miyo.Translate(domain, msg)
+}
+
+func main() {
+	ctx := context.Background()
+	flag.Parse()
+	miyo.SetLanguage(miyo.LanguageFromEnv())
+
+	if *address == "" || *apiKey == "" {
+		fmt.Fprintf(os.Stderr, tr("Usage: %s -addr=<addr> -apikey=<apikey> [-dir=<dir>]")+"\n", os.Args[0])
+		os.Exit(1)
+	}
+
+	store, err := history.Open(*dir, history.DefaultOptions)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	loc, err := miyo.LoadLocation(*timeZone)
+	if err != nil {
+		log.Fatalf("time zone: %v", err)
+	}
+	conn, err := miyo.Connect(ctx, *address, *apiKey, miyo.WithLocation(loc))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	areas, err := conn.Areas(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	p := forecast.Predictor{
+		History: store,
+		Window:  *window,
+	}
+	now := time.Now().In(conn.Location())
+	for _, c := range areas {
+		pred, err := p.Predict(c, now)
+		if err != nil {
+			fmt.Printf("%s: %v\n", c.Name, err)
+			continue
+		}
+		fmt.Printf("%s (%s)\n", pred, pred.At.Format(tr("Mon 15:04")))
+	}
+}
@@ -0,0 +1,77 @@
+// mqtt-bridge publishes the state of a MIYO Cube to an MQTT broker, using Home Assistant's MQTT discovery protocol.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/octo/miyo-go/miyo"
+	"github.com/octo/miyo-go/miyo/homeassistant"
+)
+
+var (
+	address   = flag.String("addr", os.Getenv("MIYO_ADDRESS"), "address of the Miyo cube")
+	apiKey    = flag.String("apikey", os.Getenv("MIYO_APIKEY"), "API key of the Miyo cube")
+	timeZone  = flag.String("tz", "", `time zone of the Miyo cube, e.g. "Europe/Berlin"`)
+	broker    = flag.String("broker", "tcp://localhost:1883", "URL of the MQTT broker")
+	username  = flag.String("username", os.Getenv("MQTT_USERNAME"), "MQTT user name")
+	password  = flag.String("password", os.Getenv("MQTT_PASSWORD"), "MQTT password")
+	discovery = flag.String("discovery-prefix", homeassistant.DefaultTopics.Discovery, "Home Assistant discovery prefix")
+	base      = flag.String("base-topic", homeassistant.DefaultTopics.Base, "prefix of state and command topics")
+	interval  = flag.Duration("interval", time.Minute, "interval in which the Miyo cube is polled")
+)
+
+func main() {
+	ctx := context.Background()
+	flag.Parse()
+
+	if *address == "" || *apiKey == "" {
+		fmt.Fprintf(os.Stderr, "Usage: %s -addr=<addr> -apikey=<apikey> [-broker=<url>]\n", os.Args[0])
+		os.Exit(1)
+	}
+
+	loc, err := miyo.LoadLocation(*timeZone)
+	if err != nil {
+		log.Fatalf("time zone: %v", err)
+	}
+	conn, err := miyo.Connect(ctx, *address, *apiKey, miyo.WithLocation(loc))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	b := &homeassistant.Bridge{
+		Conn: conn,
+		Topics: homeassistant.Topics{
+			Discovery: *discovery,
+			Base:      *base,
+		},
+	}
+
+	// The command topics are subscribed to on every connect: with a clean session, the broker forgets the
+	// subscriptions when the client reconnects.
+	opts := mqtt.NewClientOptions().
+		AddBroker(*broker).
+		SetClientID("miyo-bridge").
+		SetUsername(*username).
+		SetPassword(*password).
+		SetAutoReconnect(true).
+		SetOnConnectHandler(func(c mqtt.Client) {
+			if err := b.Subscribe(ctx, c); err != nil {
+				log.Print(err)
+			}
+		})
+	b.Client = mqtt.NewClient(opts)
+	if t := b.Client.Connect(); t.Wait() && t.Error() != nil {
+		log.Fatalf("connecting to %q: %v", *broker, t.Error())
+	}
+	defer b.Client.Disconnect(250)
+
+	if err := b.Run(ctx, *interval); err != nil {
+		log.Fatal(err)
+	}
+}
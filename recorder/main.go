@@ -0,0 +1,86 @@
+// recorder polls a MIYO Cube and records the state of all devices and areas in a local history store.
+// Irrigation events are recorded in the irrigation log in the same directory.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/octo/miyo-go/miyo"
+	"github.com/octo/miyo-go/miyo/history"
+	"github.com/octo/miyo-go/miyo/irrigation"
+)
+
+var (
+	address   = flag.String("addr", os.Getenv("MIYO_ADDRESS"), "address of the Miyo cube")
+	apiKey    = flag.String("apikey", os.Getenv("MIYO_APIKEY"), "API key of the Miyo cube")
+	timeZone  = flag.String("tz", "", `time zone of the Miyo cube, e.g. "Europe/Berlin"`)
+	dir       = flag.String("dir", "miyo-history", "directory of the history store")
+	interval  = flag.Duration("interval", 5*time.Minute, "interval in which the Miyo cube is polled")
+	retention = flag.Duration("retention", history.DefaultOptions.Retention, "duration after which samples are deleted")
+)
+
+// domain is the catalog domain of the command's messages.
+const domain = "recorder"
+
+func init() {
+	miyo.RegisterCatalog(domain, "de", miyo.Catalog{
+		"Usage: %s -addr=<addr> -apikey=<apikey> [-dir=<dir>]": "Aufruf: %s -addr=<Adresse> -apikey=<API-Schlüssel> [-dir=<Verzeichnis>]",
+	})
+}
+
+func main() {
+	ctx := context.Background()
+	flag.Parse()
+	miyo.SetLanguage(miyo.LanguageFromEnv())
+
+	if *address == "" || *apiKey == "" {
+		fmt.Fprintf(os.Stderr, miyo.Translate(domain, "Usage: %s -addr=<addr> -apikey=<apikey> [-dir=<dir>]")+"\n", os.Args[0])
+		os.Exit(1)
+	}
+
+	loc, err := miyo.LoadLocation(*timeZone)
+	if err != nil {
+		log.Fatalf("time zone: %v", err)
+	}
+	conn, err := miyo.Connect(ctx, *address, *apiKey, miyo.WithLocation(loc))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	opts := history.DefaultOptions
+	opts.Retention = *retention
+	store, err := history.Open(*dir, opts)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	irrigationLog, err := irrigation.OpenLog(filepath.Join(*dir, "irrigation.jsonl"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var lastCompact time.Time
+	err = conn.Poll(ctx, *interval, func(snap miyo.Snapshot) error {
+		if err := store.Record(snap); err != nil {
+			return err
+		}
+		if err := irrigationLog.Record(snap); err != nil {
+			return err
+		}
+
+		if snap.Time.Sub(lastCompact) < 24*time.Hour {
+			return nil
+		}
+		lastCompact = snap.Time
+		return store.Compact(snap.Time)
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -15,12 +15,29 @@ var (
 	apiKey  = flag.String("apikey", os.Getenv("MIYO_APIKEY"), "API key of the Miyo cube")
 )
 
+// domain is the catalog domain of the command's messages.
+const domain = "sample"
+
+func init() {
+	miyo.RegisterCatalog(domain, "de", miyo.Catalog{
+		"Usage: %s -addr=<addr> -apikey=<apikey>": "Aufruf: %s -addr=<Adresse> -apikey=<API-Schlüssel>",
+		"# Circuits": "# Bereiche",
+		"# Devices":  "# Geräte",
+	})
+}
+
+// tr translates msg into the current language.
+func tr(msg string) string {
+	return miyo.Translate(domain, msg)
+}
+
 func main() {
 	ctx := context.Background()
 	flag.Parse()
+	miyo.SetLanguage(miyo.LanguageFromEnv())
 
 	if *address == "" || *apiKey == "" {
-		fmt.Fprintf(os.Stderr, "Usage: %s -addr=<addr> -apikey=<apikey>\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, tr("Usage: %s -addr=<addr> -apikey=<apikey>")+"\n", os.Args[0])
 		os.Exit(1)
 	}
 
@@ -29,7 +46,7 @@ func main() {
 		log.Fatal(err)
 	}
 
-	fmt.Println("# Circuits")
+	fmt.Println(tr("# Circuits"))
 	fmt.Println()
 	cc, err := conn.Areas(ctx)
 	if err != nil {
@@ -40,7 +57,7 @@ func main() {
 	}
 	fmt.Println()
 
-	fmt.Println("# Devices")
+	fmt.Println(tr("# Devices"))
 	fmt.Println()
 	devs, err := conn.Devices(ctx)
 	if err != nil {
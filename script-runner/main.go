@@ -0,0 +1,67 @@
+// script-runner polls a MIYO Cube and calls Starlark scripts with every snapshot.
+// See package github.com/octo/miyo-go/miyo/script for the functions available to scripts.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/octo/miyo-go/miyo"
+	"github.com/octo/miyo-go/miyo/script"
+)
+
+var (
+	address  = flag.String("addr", os.Getenv("MIYO_ADDRESS"), "address of the Miyo cube")
+	apiKey   = flag.String("apikey", os.Getenv("MIYO_APIKEY"), "API key of the Miyo cube")
+	timeZone = flag.String("tz", "", `time zone of the Miyo cube, e.g. "Europe/Berlin"`)
+	dryRun   = flag.Bool("dry-run", false, "report actions without executing them")
+	interval = flag.Duration("interval", time.Minute, "interval in which the Miyo cube is polled")
+	timeout  = flag.Duration("timeout", script.DefaultLimits.Timeout, "maximum run time of a script on each poll")
+)
+
+func main() {
+	ctx := context.Background()
+	flag.Parse()
+
+	if *address == "" || *apiKey == "" || flag.NArg() == 0 {
+		fmt.Fprintf(os.Stderr, "Usage: %s -addr=<addr> -apikey=<apikey> [-dry-run] <script.star>...\n", os.Args[0])
+		os.Exit(1)
+	}
+
+	var scripts []*script.Script
+	for _, fileName := range flag.Args() {
+		src, err := os.ReadFile(fileName)
+		if err != nil {
+			log.Fatal(err)
+		}
+		s, err := script.Load(fileName, src)
+		if err != nil {
+			log.Fatal(err)
+		}
+		scripts = append(scripts, s)
+	}
+
+	loc, err := miyo.LoadLocation(*timeZone)
+	if err != nil {
+		log.Fatalf("time zone: %v", err)
+	}
+	conn, err := miyo.Connect(ctx, *address, *apiKey, miyo.WithLocation(loc))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	limits := script.DefaultLimits
+	limits.Timeout = *timeout
+
+	r := &script.Runner{
+		Scripts:    scripts,
+		Controller: conn,
+		Limits:     limits,
+		DryRun:     *dryRun,
+	}
+	log.Fatal(conn.Poll(ctx, *interval, r.Update))
+}
@@ -0,0 +1,151 @@
+// server serves a web dashboard and a JSON REST API for a MIYO Cube.
+// All clients share one cached connection to the MIYO Cube.
+//
+// With -tokens, clients have to authenticate with a token issued by -issue-token. Tokens have a role and can be
+// limited to some areas:
+//
+//	server -tokens=tokens.json -issue-token=gardener -role=irrigate -areas=Rosen,Rasen
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/octo/miyo-go/miyo"
+	"github.com/octo/miyo-go/miyo/gateway"
+	"github.com/octo/miyo-go/miyo/history"
+	"github.com/octo/miyo-go/miyo/server"
+)
+
+var (
+	address    = flag.String("addr", os.Getenv("MIYO_ADDRESS"), "address of the Miyo cube")
+	apiKey     = flag.String("apikey", os.Getenv("MIYO_APIKEY"), "API key of the Miyo cube")
+	timeZone   = flag.String("tz", "", `time zone of the Miyo cube, e.g. "Europe/Berlin"`)
+	listen     = flag.String("listen", ":8080", "address to listen on")
+	historyDir = flag.String("history", "", "directory of the history store; enables history charts")
+	interval   = flag.Duration("interval", time.Minute, "interval in which the Miyo cube is polled")
+	tokensFile = flag.String("tokens", "", "JSON file with access tokens; enables authentication")
+	auditFile  = flag.String("audit", "", "file the audit log is appended to; required with -tokens")
+	issueToken = flag.String("issue-token", "", "add a token with this name to the tokens file, print its secret and exit")
+	role       = flag.String("role", "read-only", `role of the issued token: "read-only", "irrigate" or "admin"`)
+	areas      = flag.String("areas", "", "comma separated list of area names or IDs the issued token is limited to")
+)
+
+func issue() {
+	if *tokensFile == "" {
+		log.Fatal("-issue-token requires -tokens")
+	}
+
+	var r gateway.Role
+	if err := r.UnmarshalText([]byte(*role)); err != nil {
+		log.Fatal(err)
+	}
+	var circuits []string
+	if *areas != "" {
+		circuits = strings.Split(*areas, ",")
+	}
+
+	tokens, err := gateway.LoadTokens(*tokensFile)
+	if err != nil && !os.IsNotExist(err) {
+		log.Fatal(err)
+	}
+
+	secret, t, err := gateway.NewToken(*issueToken, r, circuits)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := gateway.SaveTokens(*tokensFile, append(tokens, t)); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(secret)
+}
+
+func main() {
+	ctx := context.Background()
+	flag.Parse()
+
+	if *issueToken != "" {
+		issue()
+		return
+	}
+
+	if *address == "" || *apiKey == "" {
+		fmt.Fprintf(os.Stderr, "Usage: %s -addr=<addr> -apikey=<apikey> [-listen=<addr>] [-history=<dir>]\n", os.Args[0])
+		os.Exit(1)
+	}
+	// Every write has to be audited once others get access.
+	if *tokensFile != "" && *auditFile == "" {
+		fmt.Fprintf(os.Stderr, "Usage: %s -tokens=<file> -audit=<file> ...\n", os.Args[0])
+		os.Exit(1)
+	}
+
+	loc, err := miyo.LoadLocation(*timeZone)
+	if err != nil {
+		log.Fatalf("time zone: %v", err)
+	}
+	conn, err := miyo.Connect(ctx, *address, *apiKey, miyo.WithLocation(loc))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	srv := &server.Server{
+		Cube: conn,
+	}
+	if *historyDir != "" {
+		srv.History, err = history.Open(*historyDir, history.DefaultOptions)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	var handler http.Handler = srv.Handler()
+	if *tokensFile != "" {
+		tokens, err := gateway.LoadTokens(*tokensFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		f, err := os.OpenFile(*auditFile, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+
+		g := &gateway.Gateway{
+			Tokens: tokens,
+			Audit:  gateway.NewAuditLog(f),
+		}
+
+		srv.Policy = g
+		handler = g.Handler(handler)
+	}
+
+	go func() {
+		var lastCompact time.Time
+		err := conn.Poll(ctx, *interval, func(snap miyo.Snapshot) error {
+			if srv.History != nil {
+				if err := srv.History.Record(snap); err != nil {
+					log.Printf("recording history: %v", err)
+				}
+				// Apply retention and downsampling once a day, like recorder does.
+				if snap.Time.Sub(lastCompact) >= 24*time.Hour {
+					lastCompact = snap.Time
+					if err := srv.History.Compact(snap.Time); err != nil {
+						log.Printf("compacting history: %v", err)
+					}
+				}
+			}
+			return srv.Update(snap)
+		})
+		log.Fatal(err)
+	}()
+
+	log.Printf("Listening on %s", *listen)
+	log.Fatal(http.ListenAndServe(*listen, handler))
+}
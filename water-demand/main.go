@@ -0,0 +1,103 @@
+// water-demand estimates the water demand of each area over the last day from the temperatures recorded by
+// recorder and recommends irrigation durations.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"time"
+
+	"github.com/octo/miyo-go/miyo"
+	"github.com/octo/miyo-go/miyo/demand"
+	"github.com/octo/miyo-go/miyo/history"
+)
+
+var (
+	address   = flag.String("addr", os.Getenv("MIYO_ADDRESS"), "address of the Miyo cube")
+	apiKey    = flag.String("apikey", os.Getenv("MIYO_APIKEY"), "API key of the Miyo cube")
+	timeZone  = flag.String("tz", "", `time zone of the Miyo cube, e.g. "Europe/Berlin"`)
+	dir       = flag.String("dir", "miyo-history", "directory of the history store")
+	latitude  = flag.Float64("latitude", math.NaN(), "latitude of the garden in degrees (required)")
+	modelFile = flag.String("model", "", "JSON file overriding the default coefficients")
+)
+
+// domain is the catalog domain of the command's messages.
+const domain = "water-demand"
+
+func init() {
+	miyo.RegisterCatalog(domain, "de", miyo.Catalog{
+		"Usage: %s -addr=<addr> -apikey=<apikey> -latitude=<degrees> [-model=<file>]": "Aufruf: %s -addr=<Adresse> -apikey=<API-Schlüssel> -latitude=<Grad> [-model=<Datei>]",
+		"%s: no temperature recorded in the last 24h":                                 "%s: keine Temperatur in den letzten 24 Stunden aufgezeichnet",
+	})
+}
+
+// tr translates msg into the current language.
+func tr(msg string) string {
+	return miyo.Translate(domain, msg)
+}
+
+func main() {
+	ctx := context.Background()
+	flag.Parse()
+	miyo.SetLanguage(miyo.LanguageFromEnv())
+
+	if *address == "" || *apiKey == "" || math.IsNaN(*latitude) {
+		fmt.Fprintf(os.Stderr, tr("Usage: %s -addr=<addr> -apikey=<apikey> -latitude=<degrees> [-model=<file>]")+"\n", os.Args[0])
+		os.Exit(1)
+	}
+
+	m := demand.DefaultModel(*latitude)
+	if *modelFile != "" {
+		data, err := os.ReadFile(*modelFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := json.Unmarshal(data, &m); err != nil {
+			log.Fatalf("%s: %v", *modelFile, err)
+		}
+	}
+
+	store, err := history.Open(*dir, history.DefaultOptions)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	loc, err := miyo.LoadLocation(*timeZone)
+	if err != nil {
+		log.Fatalf("time zone: %v", err)
+	}
+	conn, err := miyo.Connect(ctx, *address, *apiKey, miyo.WithLocation(loc))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	areas, err := conn.Areas(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	to := time.Now().In(conn.Location())
+	from := to.Add(-24 * time.Hour)
+	for _, c := range areas {
+		temperature, err := store.Query(history.Query{ID: c.ID, Metric: "temperature", From: from, To: to})
+		if err != nil {
+			log.Fatal(err)
+		}
+		brightness, err := store.Query(history.Query{ID: c.ID, Metric: "brightness", From: from, To: to})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		day, ok := demand.DayFromSamples(to, temperature, brightness)
+		if !ok {
+			fmt.Printf(tr("%s: no temperature recorded in the last 24h")+"\n", c.Name)
+			continue
+		}
+		fmt.Println(m.Recommend(c, day))
+	}
+}
@@ -0,0 +1,82 @@
+// water-usage reports the amount and cost of water used for irrigation, based on the log written by recorder.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/octo/miyo-go/miyo"
+	"github.com/octo/miyo-go/miyo/irrigation"
+)
+
+var (
+	dir    = flag.String("dir", "miyo-history", "directory of the history store")
+	rates  = flag.String("rates", "", "JSON file with flow rates and the price of water")
+	period = flag.String("period", "day", `aggregation period: "day", "week" or "season"`)
+	since  = flag.Duration("since", 365*24*time.Hour, "report usage within this duration")
+)
+
+// domain is the catalog domain of the command's messages.
+const domain = "water-usage"
+
+func init() {
+	miyo.RegisterCatalog(domain, "de", miyo.Catalog{
+		"# Water usage per %s":                "# Wasserverbrauch pro %s",
+		"day":                                 "Tag",
+		"week":                                "Woche",
+		"season":                              "Saison",
+		"Usage: %s -period=(day|week|season)": "Aufruf: %s -period=(day|week|season)",
+	})
+}
+
+// tr translates msg into the current language.
+func tr(msg string) string {
+	return miyo.Translate(domain, msg)
+}
+
+func main() {
+	flag.Parse()
+	miyo.SetLanguage(miyo.LanguageFromEnv())
+
+	var r irrigation.Rates
+	if *rates != "" {
+		data, err := os.ReadFile(*rates)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := json.Unmarshal(data, &r); err != nil {
+			log.Fatalf("%s: %v", *rates, err)
+		}
+	}
+
+	periods := map[string]irrigation.Period{
+		"day":    irrigation.Period_Day,
+		"week":   irrigation.Period_Week,
+		"season": irrigation.Period_Season,
+	}
+	p, ok := periods[*period]
+	if !ok {
+		fmt.Fprintf(os.Stderr, tr("Usage: %s -period=(day|week|season)")+"\n", os.Args[0])
+		os.Exit(1)
+	}
+
+	l, err := irrigation.OpenLog(filepath.Join(*dir, "irrigation.jsonl"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	now := time.Now()
+	events := l.Events(now.Add(-*since), now)
+
+	fmt.Printf(tr("# Water usage per %s")+"\n", tr(p.String()))
+	fmt.Println()
+	for _, u := range r.Summarize(events, p) {
+		fmt.Printf("*   %s: %.0f l in %v (%.2f)\n", u.Start.Format("2006-01-02"), u.Liters, u.Duration, u.Cost)
+	}
+	fmt.Println()
+}
@@ -0,0 +1,81 @@
+// weather-check prints, for every area, whether the next irrigation planned by the MIYO Cube should go ahead,
+// be shortened or be skipped because of rain in a local weather forecast.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/octo/miyo-go/miyo"
+	"github.com/octo/miyo-go/miyo/weather"
+)
+
+var (
+	address      = flag.String("addr", os.Getenv("MIYO_ADDRESS"), "address of the Miyo cube")
+	apiKey       = flag.String("apikey", os.Getenv("MIYO_APIKEY"), "API key of the Miyo cube")
+	timeZone     = flag.String("tz", "", `time zone of the Miyo cube, e.g. "Europe/Berlin"`)
+	forecastFile = flag.String("forecast-file", "", "JSON file with the weather forecast")
+	forecastURL  = flag.String("forecast-url", "", "URL returning the weather forecast as JSON")
+	lookahead    = flag.Duration("lookahead", weather.DefaultPolicy.Lookahead, "time before the planned irrigation in which rain is considered")
+	shortenAbove = flag.Float64("shorten-above", weather.DefaultPolicy.ShortenAbove, "rain in mm above which irrigation is shortened")
+	skipAbove    = flag.Float64("skip-above", weather.DefaultPolicy.SkipAbove, "rain in mm above which irrigation is skipped")
+	apply        = flag.Bool("apply", false, "stop running irrigation of areas for which irrigation should be skipped")
+)
+
+func main() {
+	ctx := context.Background()
+	flag.Parse()
+	miyo.SetLanguage(miyo.LanguageFromEnv())
+
+	var provider weather.Provider
+	switch {
+	case *forecastFile != "":
+		provider = weather.FileProvider{FileName: *forecastFile}
+	case *forecastURL != "":
+		provider = weather.HTTPProvider{URL: *forecastURL}
+	}
+
+	if *address == "" || *apiKey == "" || provider == nil {
+		fmt.Fprintf(os.Stderr, "Usage: %s -addr=<addr> -apikey=<apikey> (-forecast-file=<file>|-forecast-url=<url>) [-apply]\n", os.Args[0])
+		os.Exit(1)
+	}
+
+	forecast, err := provider.Forecast(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	loc, err := miyo.LoadLocation(*timeZone)
+	if err != nil {
+		log.Fatalf("time zone: %v", err)
+	}
+	conn, err := miyo.Connect(ctx, *address, *apiKey, miyo.WithLocation(loc))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	areas, err := conn.Areas(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	policy := weather.Policy{
+		Lookahead:    *lookahead,
+		ShortenAbove: *shortenAbove,
+		SkipAbove:    *skipAbove,
+	}
+
+	for _, c := range areas {
+		d := weather.Decide(c, forecast, policy)
+		fmt.Printf("%s: %s\n", c.Name, d)
+
+		if *apply && d.Action == weather.Action_Skip && c.State.Irrigation {
+			if err := conn.SetIrrigation(ctx, c.ID, false); err != nil {
+				log.Printf("%s: %v", c.Name, err)
+			}
+		}
+	}
+}